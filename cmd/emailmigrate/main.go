@@ -0,0 +1,116 @@
+// emailmigrate 检查 users 表中已有邮箱的规范形式，报告规范化后互相冲突的账号。
+// 默认只输出报告，加上 -apply 后把没有冲突的邮箱改写为规范形式；存在冲突时以状态码 2 退出，
+// 冲突需要人工处理后再执行。
+//
+// 上线邮箱规范化之前以及修改 providerRules 之前都必须执行：服务只对规范形式查询后按原始写法兜底，
+// 未改写的存量邮箱用其他写法登录或注册时无法匹配，可能注册出重复账号。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/foundation/emailaddr"
+	"github.com/TiktokCommence/userService/internal/model"
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+const batchSize = 1000
+
+var (
+	flagconf  string
+	flagApply bool
+)
+
+func init() {
+	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
+	flag.BoolVar(&flagApply, "apply", false, "rewrite emails without collisions to their canonical form")
+}
+
+func main() {
+	flag.Parse()
+	c := config.New(
+		config.WithSource(
+			file.NewSource(flagconf),
+		),
+	)
+	defer c.Close()
+
+	if err := c.Load(); err != nil {
+		panic(err)
+	}
+	var bc conf.Bootstrap
+	if err := c.Scan(&bc); err != nil {
+		panic(err)
+	}
+
+	db, err := gorm.Open(mysql.Open(bc.Data.Database.Source), &gorm.Config{})
+	if err != nil {
+		panic(err)
+	}
+	records, err := loadRecords(db)
+	if err != nil {
+		panic(err)
+	}
+	canonicalizer := emailaddr.New(emailaddr.WithProviderRules(bc.Email.GetProviderRules()))
+	report := canonicalizer.Plan(records)
+	printReport(len(records), report)
+
+	if flagApply {
+		applied := 0
+		for _, r := range report.Rewrites {
+			res := db.Table(model.UserTableName).Where("id = ? AND email = ?", r.ID, r.Email).Update("email", r.Canonical)
+			if res.Error != nil {
+				fmt.Fprintf(os.Stderr, "rewrite user %d: %v\n", r.ID, res.Error)
+				continue
+			}
+			applied += int(res.RowsAffected)
+		}
+		// 缓存中的用户信息会在过期后重新从数据库加载
+		fmt.Printf("rewrote %d of %d emails\n", applied, len(report.Rewrites))
+	}
+	if len(report.Collisions) > 0 {
+		os.Exit(2)
+	}
+}
+
+// loadRecords 按 ID 分批读取全部账号的邮箱，避免一次性加载整张表
+func loadRecords(db *gorm.DB) ([]emailaddr.Record, error) {
+	var records []emailaddr.Record
+	var lastID uint64
+	for {
+		var batch []emailaddr.Record
+		err := db.Table(model.UserTableName).Select("id", "email").
+			Where("id > ?", lastID).Order("id").Limit(batchSize).Find(&batch).Error
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, batch...)
+		if len(batch) < batchSize {
+			return records, nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+func printReport(total int, report emailaddr.Report) {
+	fmt.Printf("checked %d users: %d collisions, %d to rewrite, %d invalid\n",
+		total, len(report.Collisions), len(report.Rewrites), len(report.Invalid))
+	for _, c := range report.Collisions {
+		fmt.Printf("collision %s:\n", c.Canonical)
+		for _, r := range c.Records {
+			fmt.Printf("  user %d %s\n", r.ID, r.Email)
+		}
+	}
+	for _, r := range report.Rewrites {
+		fmt.Printf("rewrite user %d %s -> %s\n", r.ID, r.Email, r.Canonical)
+	}
+	for _, r := range report.Invalid {
+		fmt.Printf("invalid user %d %q\n", r.ID, r.Email)
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"os"
 
 	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/server"
 
 	"github.com/go-kratos/kratos/v2/config"
 	"github.com/go-kratos/kratos/v2/config/file"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
+	"github.com/go-kratos/kratos/v2/transport/http"
 	_ "go.uber.org/automaxprocs"
 )
 
@@ -34,7 +36,7 @@ func init() {
 	flag.StringVar(&flagLog, "log", "app.log", "log file path, eg: -log logs/app.log")
 }
 
-func newApp(logger log.Logger, gs *grpc.Server, r *etcd.Registry) *kratos.App {
+func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, ob *server.OutboxServer, ci *server.CacheInvalidationServer, r *etcd.Registry) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -43,6 +45,9 @@ func newApp(logger log.Logger, gs *grpc.Server, r *etcd.Registry) *kratos.App {
 		kratos.Logger(logger),
 		kratos.Server(
 			gs,
+			hs,
+			ob,
+			ci,
 		),
 		kratos.Registrar(r),
 	)
@@ -72,7 +77,7 @@ func main() {
 		"service.version", Version,
 	)
 
-	app, cleanup, err := wireApp(bc.Server, bc.Data, bc.Email, bc.Registry, logger)
+	app, cleanup, err := wireApp(bc.Server, bc.Data, bc.Email, bc.Registry, bc.Password, bc.Token, bc.Lockout, bc.TwoFactor, bc.VerifyCode, bc.Outbox, bc.Sms, bc.Id, bc.PublicID, bc.Cache, logger)
 	if err != nil {
 		panic(err)
 	}
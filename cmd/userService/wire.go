@@ -9,15 +9,19 @@ import (
 	"github.com/TiktokCommence/userService/internal/biz"
 	"github.com/TiktokCommence/userService/internal/conf"
 	"github.com/TiktokCommence/userService/internal/data"
+	"github.com/TiktokCommence/userService/internal/foundation/clientip"
+	"github.com/TiktokCommence/userService/internal/foundation/password"
+	"github.com/TiktokCommence/userService/internal/foundation/publicid"
 	"github.com/TiktokCommence/userService/internal/registry"
 	"github.com/TiktokCommence/userService/internal/server"
 	"github.com/TiktokCommence/userService/internal/service"
+	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
 )
 
 // wireApp init kratos application.
-func wireApp(*conf.Server, *conf.Data, *conf.EmailConf, *conf.RegistryConf, log.Logger) (*kratos.App, func(), error) {
+func wireApp(*conf.Server, *conf.Data, *conf.EmailConf, *conf.RegistryConf, *conf.PasswordConf, *conf.TokenConf, *conf.LockoutConf, *conf.TwoFactorConf, *conf.VerifyCodeConf, *conf.OutboxConf, *conf.SmsConf, *conf.IDConf, *conf.PublicIDConf, *conf.CacheConf, log.Logger) (*kratos.App, func(), error) {
 	panic(wire.Build(
 		server.ProviderSet,
 		service.ProviderSet,
@@ -26,9 +30,28 @@ func wireApp(*conf.Server, *conf.Data, *conf.EmailConf, *conf.RegistryConf, log.
 		registry.ProviderSet,
 		newApp,
 		wire.Bind(new(service.UserHandler), new(*biz.UserHandler)),
-		wire.Bind(new(biz.GenerateID), new(*data.RedisWorkerImplement)),
 		wire.Bind(new(biz.EmailWorker), new(*data.EmailWorker)),
+		wire.Bind(new(biz.SmsWorker), new(*data.SmsWorker)),
+		wire.Bind(new(biz.EmailChangeWorker), new(*data.EmailChangeWorker)),
+		wire.Bind(new(biz.EmailCanonicalizer), new(*data.EmailCanonicalizer)),
 		wire.Bind(new(biz.DBWorker), new(*data.UserRepo)),
-		wire.Bind(new(biz.RedisWorker), new(*data.RedisWorkerImplement)),
+		wire.Bind(new(biz.RedisWorker), new(*data.LocalUserCache)),
+		wire.Bind(new(biz.LoadLock), new(*data.LoadLock)),
+		wire.Bind(new(biz.PasswordHasher), new(*password.Manager)),
+		wire.Bind(new(biz.PasswordPolicy), new(*data.PasswordPolicy)),
+		wire.Bind(new(biz.LoginLimiter), new(*data.LoginLimiter)),
+		wire.Bind(new(service.TwoFactorHandler), new(*biz.TwoFactorHandler)),
+		wire.Bind(new(biz.TwoFactorRepo), new(*data.TwoFactorRepo)),
+		wire.Bind(new(biz.TOTPWorker), new(*data.TOTPWorker)),
+		wire.Bind(new(biz.ChallengeWorker), new(*data.ChallengeWorker)),
+		wire.Bind(new(server.OutboxDispatcher), new(*data.Outbox)),
+		wire.Bind(new(server.CacheInvalidationListener), new(*data.LocalUserCache)),
+		wire.Bind(new(server.CacheStats), new(*data.LocalUserCache)),
+		wire.Bind(new(service.AuthHandler), new(*biz.AuthHandler)),
+		wire.Bind(new(service.PublicIDCodec), new(*publicid.Codec)),
+		wire.Bind(new(service.ClientIPResolver), new(*clientip.Resolver)),
+		wire.Bind(new(biz.TokenWorker), new(*data.TokenWorker)),
+		wire.Bind(new(biz.SessionWorker), new(*data.SessionWorker)),
+		wire.Bind(new(biz.IntrospectionCache), new(*data.IntrospectionCache)),
 	))
 }
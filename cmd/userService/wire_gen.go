@@ -24,21 +24,89 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, confData *conf.Data, emailConf *conf.EmailConf, registryConf *conf.RegistryConf, logger log.Logger) (*kratos.App, func(), error) {
+func wireApp(confServer *conf.Server, confData *conf.Data, emailConf *conf.EmailConf, registryConf *conf.RegistryConf, passwordConf *conf.PasswordConf, tokenConf *conf.TokenConf, lockoutConf *conf.LockoutConf, twoFactorConf *conf.TwoFactorConf, verifyCodeConf *conf.VerifyCodeConf, outboxConf *conf.OutboxConf, smsConf *conf.SmsConf, idConf *conf.IDConf, publicIDConf *conf.PublicIDConf, cacheConf *conf.CacheConf, logger log.Logger) (*kratos.App, func(), error) {
+	db, err := data.NewDB(confData)
+	if err != nil {
+		return nil, nil, err
+	}
 	cache := data.NewCache(confData)
 	options := data.NewOptions(confData)
 	redisWorkerImplement := data.NewRedisWorkerImplement(cache, options, logger)
-	db, err := data.NewDB(confData)
+	generateID, cleanup, err := data.NewIDGenerator(idConf, db, cache, redisWorkerImplement, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	localUserCache := data.NewLocalUserCache(cacheConf, redisWorkerImplement, logger)
+	emailCanonicalizer := data.NewEmailCanonicalizer(emailConf)
+	userRepo := data.NewUserRepo(db, emailCanonicalizer, logger)
+	store := data.NewVerifyCodeStore(cache, verifyCodeConf, emailConf)
+	mailer, err := data.NewMailer(emailConf)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	outbox := data.NewOutbox(db, mailer, outboxConf, logger)
+	renderer, err := data.NewMailRenderer(emailConf)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	emailWorker := data.NewEmailWorker(store, outbox, renderer)
+	sender, err := data.NewSmsSender(smsConf, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	smsWorker := data.NewSmsWorker(store, sender, smsConf)
+	manager, err := data.NewPasswordHasher(passwordConf)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	passwordPolicy, err := data.NewPasswordPolicy(passwordConf)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	loginLimiter := data.NewLoginLimiter(cache, lockoutConf)
+	emailChangeWorker := data.NewEmailChangeWorker(cache, store)
+	loadLock := data.NewLoadLock(cacheConf, cache, logger)
+	userHandler := biz.NewUserHandler(generateID, localUserCache, userRepo, emailWorker, smsWorker, manager, passwordPolicy, loginLimiter, emailChangeWorker, emailCanonicalizer, loadLock, logger)
+	tokenManager, err := data.NewTokenManager(tokenConf)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	tokenWorker := data.NewTokenWorker(cache, tokenManager)
+	sessionWorker := data.NewSessionWorker(cache, tokenManager)
+	introspectionCache := data.NewIntrospectionCache(tokenConf)
+	authHandler := biz.NewAuthHandler(tokenWorker, sessionWorker, introspectionCache, logger)
+	twoFactorRepo := data.NewTwoFactorRepo(db)
+	totpWorker := data.NewTOTPWorker(twoFactorConf)
+	challengeWorker := data.NewChallengeWorker(cache, twoFactorConf)
+	twoFactorHandler := biz.NewTwoFactorHandler(twoFactorRepo, totpWorker, challengeWorker, userRepo, manager, loginLimiter, logger)
+	codec, err := data.NewPublicIDCodec(publicIDConf)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	resolver, err := data.NewClientIPResolver(confServer)
 	if err != nil {
+		cleanup()
 		return nil, nil, err
 	}
-	userRepo := data.NewUserRepo(db, logger)
-	emailWorker := data.NewEmailWorker(cache, emailConf)
-	userHandler := biz.NewUserHandler(redisWorkerImplement, redisWorkerImplement, userRepo, emailWorker, logger)
-	userServiceService := service.NewUserServiceService(userHandler)
+	userServiceService := service.NewUserServiceService(userHandler, authHandler, twoFactorHandler, codec, resolver)
 	grpcServer := server.NewGRPCServer(confServer, userServiceService, logger)
+	httpServer, err := server.NewHTTPServer(confServer, verifyCodeConf, cacheConf, userServiceService, localUserCache, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	outboxServer := server.NewOutboxServer(outboxConf, outbox, logger)
+	cacheInvalidationServer := server.NewCacheInvalidationServer(localUserCache, logger)
 	etcdRegistry := registry.NewRegistrarServer(registryConf, logger)
-	app := newApp(logger, grpcServer, etcdRegistry)
+	app := newApp(logger, grpcServer, httpServer, outboxServer, cacheInvalidationServer, etcdRegistry)
 	return app, func() {
+		cleanup()
 	}, nil
 }
@@ -0,0 +1,206 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	"github.com/TiktokCommence/userService/internal/model"
+	"github.com/TiktokCommence/userService/internal/service"
+	"github.com/go-kratos/kratos/v2/log"
+	"time"
+)
+
+var _ service.AuthHandler = (*AuthHandler)(nil)
+
+type TokenWorker interface {
+	IssueAccessToken(userID uint64, sessionID string, roles []string) (string, model.TokenClaims, error)
+	ParseAccessToken(accessToken string) (model.TokenClaims, error)
+	CreateRefreshToken(ctx context.Context, userID uint64, sessionID string) (string, time.Time, error)
+	// 刷新令牌只能使用一次，读取后立即失效
+	ConsumeRefreshToken(ctx context.Context, refreshToken string) (model.RefreshToken, error)
+	// 当前可用于验签的公钥
+	JWKS() []model.JWK
+}
+
+type SessionWorker interface {
+	CreateSession(ctx context.Context, userID uint64, roles []string, client model.ClientInfo) (model.Session, error)
+	GetSession(ctx context.Context, sessionID string) (model.Session, error)
+	TouchSession(ctx context.Context, session model.Session, client model.ClientInfo) (model.Session, error)
+	ListSessions(ctx context.Context, userID uint64) ([]model.Session, error)
+	RevokeSession(ctx context.Context, userID uint64, sessionID string) error
+}
+
+// 进程内的令牌校验结果缓存，避免热点令牌每次校验都访问 redis
+type IntrospectionCache interface {
+	Get(accessToken string) (model.TokenIntrospection, bool)
+	Set(accessToken string, result model.TokenIntrospection, ttl time.Duration)
+}
+
+type AuthHandler struct {
+	t  TokenWorker
+	s  SessionWorker
+	ic IntrospectionCache
+	h  *log.Helper
+}
+
+func NewAuthHandler(t TokenWorker, s SessionWorker, ic IntrospectionCache, logger log.Logger) *AuthHandler {
+	return &AuthHandler{
+		t:  t,
+		s:  s,
+		ic: ic,
+		h:  log.NewHelper(logger),
+	}
+}
+
+// IssueTokens 为登录成功的用户创建会话并签发令牌对
+func (a *AuthHandler) IssueTokens(ctx context.Context, user model.User, client model.ClientInfo) (model.TokenPair, error) {
+	session, err := a.s.CreateSession(ctx, user.ID, user.RoleList(), client)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+	return a.issueTokens(ctx, session)
+}
+
+// RefreshTokens 使用刷新令牌换取新的令牌对，旧的刷新令牌随之失效
+func (a *AuthHandler) RefreshTokens(ctx context.Context, refreshToken string, client model.ClientInfo) (model.TokenPair, error) {
+	rt, err := a.t.ConsumeRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+	session, err := a.s.GetSession(ctx, rt.SessionID)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+	if session.UserID != rt.UserID {
+		return model.TokenPair{}, errcode.TokenInvalid
+	}
+	if session, err = a.s.TouchSession(ctx, session, client); err != nil {
+		a.h.Warnf("touch session %s of user %d error:%v", session.ID, session.UserID, err)
+	}
+	return a.issueTokens(ctx, session)
+}
+
+// VerifyAccessToken 校验访问令牌的签名与有效期，并确认其所属会话未被撤销
+func (a *AuthHandler) VerifyAccessToken(ctx context.Context, accessToken string) (model.TokenClaims, error) {
+	claims, err := a.t.ParseAccessToken(accessToken)
+	if err != nil {
+		return model.TokenClaims{}, err
+	}
+	session, err := a.s.GetSession(ctx, claims.SessionID)
+	if err != nil {
+		return model.TokenClaims{}, err
+	}
+	if session.UserID != claims.UserID {
+		return model.TokenClaims{}, errcode.TokenInvalid
+	}
+	return claims, nil
+}
+
+// IntrospectToken 返回访问令牌的校验结果与声明，结果会在进程内短暂缓存，
+// 因此会话撤销最多延迟一个缓存周期后才对网关生效
+func (a *AuthHandler) IntrospectToken(ctx context.Context, accessToken string) (model.TokenIntrospection, error) {
+	if result, ok := a.ic.Get(accessToken); ok {
+		return result, nil
+	}
+	result := model.TokenIntrospection{State: model.TokenStateActive}
+	claims, err := a.VerifyAccessToken(ctx, accessToken)
+	switch {
+	case err == nil:
+		result.Active = true
+		result.Claims = claims
+	case errors.Is(err, errcode.TokenExpired):
+		result.State = model.TokenStateExpired
+	case errors.Is(err, errcode.SessionRevoked):
+		result.State = model.TokenStateRevoked
+	case errors.Is(err, errcode.TokenInvalid):
+		result.State = model.TokenStateInvalid
+	default:
+		return model.TokenIntrospection{}, err
+	}
+	ttl := time.Until(claims.ExpiresAt)
+	if !result.Active {
+		// 无效令牌的结果同样缓存，避免伪造令牌反复击穿到 redis
+		ttl = time.Minute
+	}
+	a.ic.Set(accessToken, result, ttl)
+	return result, nil
+}
+
+// Logout 撤销访问令牌所属的会话，会话下的访问令牌与刷新令牌全部失效
+func (a *AuthHandler) Logout(ctx context.Context, userID uint64, accessToken string) error {
+	claims, err := a.VerifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	if userID != InvalidID && claims.UserID != userID {
+		return errcode.TokenInvalid
+	}
+	if err = a.s.RevokeSession(ctx, claims.UserID, claims.SessionID); err != nil {
+		return fmt.Errorf("revoke session %s of user %d err:%w", claims.SessionID, claims.UserID, err)
+	}
+	return nil
+}
+
+func (a *AuthHandler) ListSessions(ctx context.Context, userID uint64) ([]model.Session, error) {
+	return a.s.ListSessions(ctx, userID)
+}
+
+// RevokeSession 撤销用户的某个会话，不属于该用户的会话视为不存在
+func (a *AuthHandler) RevokeSession(ctx context.Context, userID uint64, sessionID string) error {
+	session, err := a.s.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return errcode.SessionRevoked
+	}
+	if err = a.s.RevokeSession(ctx, userID, sessionID); err != nil {
+		return fmt.Errorf("revoke session %s of user %d err:%w", sessionID, userID, err)
+	}
+	return nil
+}
+
+// RevokeAllSessions 撤销用户除 exceptSessionID 以外的全部会话，返回撤销的会话数量
+func (a *AuthHandler) RevokeAllSessions(ctx context.Context, userID uint64, exceptSessionID string) (int, error) {
+	sessions, err := a.s.ListSessions(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("list sessions of user %d err:%w", userID, err)
+	}
+	revoked := 0
+	for _, session := range sessions {
+		if session.ID == exceptSessionID {
+			continue
+		}
+		if err = a.s.RevokeSession(ctx, userID, session.ID); err != nil {
+			return revoked, fmt.Errorf("revoke session %s of user %d err:%w", session.ID, userID, err)
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// GetJWKS 返回验签公钥，其他服务据此离线校验访问令牌
+func (a *AuthHandler) GetJWKS(ctx context.Context) []model.JWK {
+	return a.t.JWKS()
+}
+
+func (a *AuthHandler) issueTokens(ctx context.Context, session model.Session) (model.TokenPair, error) {
+	userID, sessionID := session.UserID, session.ID
+	accessToken, claims, err := a.t.IssueAccessToken(userID, sessionID, session.Roles)
+	if err != nil {
+		return model.TokenPair{}, fmt.Errorf("issue access token for user %d err:%w", userID, err)
+	}
+	refreshToken, refreshExpiresAt, err := a.t.CreateRefreshToken(ctx, userID, sessionID)
+	if err != nil {
+		return model.TokenPair{}, fmt.Errorf("create refresh token for user %d err:%w", userID, err)
+	}
+	return model.TokenPair{
+		UserID:           userID,
+		SessionID:        sessionID,
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		AccessExpiresAt:  claims.ExpiresAt,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
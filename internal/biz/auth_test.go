@@ -0,0 +1,252 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	"github.com/TiktokCommence/userService/internal/model"
+	"github.com/go-kratos/kratos/v2/log"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTokens 测试使用的令牌，访问令牌与刷新令牌都是递增编号，刷新令牌读取后删除
+type fakeTokens struct {
+	mu      sync.Mutex
+	n       int
+	access  map[string]model.TokenClaims
+	refresh map[string]model.RefreshToken
+}
+
+func newFakeTokens() *fakeTokens {
+	return &fakeTokens{access: make(map[string]model.TokenClaims), refresh: make(map[string]model.RefreshToken)}
+}
+
+func (f *fakeTokens) IssueAccessToken(userID uint64, sessionID string, roles []string) (string, model.TokenClaims, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.n++
+	token := fmt.Sprintf("access-%d", f.n)
+	claims := model.TokenClaims{ID: token, UserID: userID, SessionID: sessionID, Roles: roles, ExpiresAt: time.Now().Add(time.Hour)}
+	f.access[token] = claims
+	return token, claims, nil
+}
+
+func (f *fakeTokens) ParseAccessToken(accessToken string) (model.TokenClaims, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	claims, ok := f.access[accessToken]
+	if !ok {
+		return model.TokenClaims{}, errcode.TokenInvalid
+	}
+	return claims, nil
+}
+
+func (f *fakeTokens) CreateRefreshToken(ctx context.Context, userID uint64, sessionID string) (string, time.Time, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.n++
+	token := fmt.Sprintf("refresh-%d", f.n)
+	expiresAt := time.Now().Add(24 * time.Hour)
+	f.refresh[token] = model.RefreshToken{UserID: userID, SessionID: sessionID, ExpiresAt: expiresAt}
+	return token, expiresAt, nil
+}
+
+func (f *fakeTokens) ConsumeRefreshToken(ctx context.Context, refreshToken string) (model.RefreshToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rt, ok := f.refresh[refreshToken]
+	if !ok {
+		return model.RefreshToken{}, errcode.TokenInvalid
+	}
+	delete(f.refresh, refreshToken)
+	return rt, nil
+}
+
+func (f *fakeTokens) JWKS() []model.JWK {
+	return nil
+}
+
+// fakeSessions 测试使用的会话存储
+type fakeSessions struct {
+	mu       sync.Mutex
+	n        int
+	sessions map[string]model.Session
+}
+
+func newFakeSessions() *fakeSessions {
+	return &fakeSessions{sessions: make(map[string]model.Session)}
+}
+
+func (f *fakeSessions) CreateSession(ctx context.Context, userID uint64, roles []string, client model.ClientInfo) (model.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.n++
+	s := model.Session{ID: fmt.Sprintf("session-%d", f.n), UserID: userID, Roles: roles, Device: client.Device, IP: client.IP}
+	f.sessions[s.ID] = s
+	return s, nil
+}
+
+func (f *fakeSessions) GetSession(ctx context.Context, sessionID string) (model.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.sessions[sessionID]
+	if !ok {
+		return model.Session{}, errcode.SessionRevoked
+	}
+	return s, nil
+}
+
+func (f *fakeSessions) TouchSession(ctx context.Context, session model.Session, client model.ClientInfo) (model.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	session.IP = client.IP
+	f.sessions[session.ID] = session
+	return session, nil
+}
+
+func (f *fakeSessions) ListSessions(ctx context.Context, userID uint64) ([]model.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var sessions []model.Session
+	for _, s := range f.sessions {
+		if s.UserID == userID {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
+}
+
+func (f *fakeSessions) RevokeSession(ctx context.Context, userID uint64, sessionID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok := f.sessions[sessionID]; ok && s.UserID == userID {
+		delete(f.sessions, sessionID)
+	}
+	return nil
+}
+
+// noIntrospectionCache 不缓存校验结果
+type noIntrospectionCache struct{}
+
+func (noIntrospectionCache) Get(accessToken string) (model.TokenIntrospection, bool) {
+	return model.TokenIntrospection{}, false
+}
+func (noIntrospectionCache) Set(accessToken string, result model.TokenIntrospection, ttl time.Duration) {
+}
+
+func newTestAuthHandler() (*AuthHandler, *fakeTokens, *fakeSessions) {
+	tokens, sessions := newFakeTokens(), newFakeSessions()
+	return NewAuthHandler(tokens, sessions, noIntrospectionCache{}, log.DefaultLogger), tokens, sessions
+}
+
+func TestAuthHandler_RefreshTokens(t *testing.T) {
+	ctx := context.Background()
+	a, tokens, sessions := newTestAuthHandler()
+	pair, err := a.IssueTokens(ctx, model.User{ID: 1}, model.ClientInfo{IP: "1.1.1.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 刷新后换成新的令牌对，会话不变
+	rotated, err := a.RefreshTokens(ctx, pair.RefreshToken, model.ClientInfo{IP: "2.2.2.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.RefreshToken == pair.RefreshToken || rotated.AccessToken == pair.AccessToken {
+		t.Fatalf("expected a new token pair, got %+v", rotated)
+	}
+	if rotated.SessionID != pair.SessionID || rotated.UserID != 1 {
+		t.Fatalf("expected the same session of user 1, got %+v", rotated)
+	}
+	if s, _ := sessions.GetSession(ctx, pair.SessionID); s.IP != "2.2.2.2" {
+		t.Errorf("expected session to be touched, got ip %q", s.IP)
+	}
+
+	// 另一个会话的刷新令牌指向的用户与会话不一致时拒绝
+	other, err := a.IssueTokens(ctx, model.User{ID: 2}, model.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged, _, _ := tokens.CreateRefreshToken(ctx, 1, other.SessionID)
+	// 会话撤销后刷新令牌不能再使用
+	revoked, err := a.IssueTokens(ctx, model.User{ID: 3}, model.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = a.Logout(ctx, 3, revoked.AccessToken); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		refresh string
+		expect  error
+	}{
+		// 旧的刷新令牌只能使用一次
+		{"reuse", pair.RefreshToken, errcode.TokenInvalid},
+		{"unknown", "refresh-unknown", errcode.TokenInvalid},
+		{"user mismatch", forged, errcode.TokenInvalid},
+		{"revoked session", revoked.RefreshToken, errcode.SessionRevoked},
+		{"rotated", rotated.RefreshToken, nil},
+	}
+	for _, c := range cases {
+		_, err := a.RefreshTokens(ctx, c.refresh, model.ClientInfo{})
+		if !errors.Is(err, c.expect) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expect, err)
+		}
+	}
+}
+
+func TestAuthHandler_Sessions(t *testing.T) {
+	ctx := context.Background()
+	a, _, _ := newTestAuthHandler()
+	var pairs []model.TokenPair
+	for _, id := range []uint64{1, 1, 1, 2} {
+		pair, err := a.IssueTokens(ctx, model.User{ID: id}, model.ClientInfo{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pairs = append(pairs, pair)
+	}
+
+	// 不属于该用户的会话视为不存在
+	if err := a.RevokeSession(ctx, 1, pairs[3].SessionID); !errors.Is(err, errcode.SessionRevoked) {
+		t.Errorf("expected revoking another user's session to fail, got %v", err)
+	}
+	if err := a.RevokeSession(ctx, 1, pairs[1].SessionID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.VerifyAccessToken(ctx, pairs[1].AccessToken); !errors.Is(err, errcode.SessionRevoked) {
+		t.Errorf("expected access token of revoked session to be rejected, got %v", err)
+	}
+
+	n, err := a.RevokeAllSessions(ctx, 1, pairs[0].SessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 session revoked, got %d", n)
+	}
+	cases := []struct {
+		name   string
+		access string
+		expect error
+	}{
+		{"kept", pairs[0].AccessToken, nil},
+		{"revoked", pairs[2].AccessToken, errcode.SessionRevoked},
+		{"other user", pairs[3].AccessToken, nil},
+		{"forged", "access-unknown", errcode.TokenInvalid},
+	}
+	for _, c := range cases {
+		if _, err := a.VerifyAccessToken(ctx, c.access); !errors.Is(err, c.expect) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expect, err)
+		}
+	}
+
+	// 退出登录时令牌需要属于请求中的用户
+	if err = a.Logout(ctx, 2, pairs[0].AccessToken); !errors.Is(err, errcode.TokenInvalid) {
+		t.Errorf("expected logout with another user's token to fail, got %v", err)
+	}
+}
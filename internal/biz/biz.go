@@ -5,4 +5,4 @@ import (
 )
 
 // ProviderSet is biz providers.
-var ProviderSet = wire.NewSet(NewUserHandler)
+var ProviderSet = wire.NewSet(NewUserHandler, NewAuthHandler, NewTwoFactorHandler)
@@ -0,0 +1,67 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	"github.com/TiktokCommence/userService/internal/model"
+	"time"
+)
+
+// 修改邮箱时记录待确认的新邮箱
+type EmailChangeWorker interface {
+	SavePending(ctx context.Context, userID uint64, newEmail string) error
+	Pending(ctx context.Context, userID uint64) (string, error)
+	ClearPending(ctx context.Context, userID uint64) error
+}
+
+// RequestEmailChange 校验当前密码后向新邮箱发送验证码，返回距离下一次可以发送的时长
+func (u *UserHandler) RequestEmailChange(ctx context.Context, userID uint64, newEmail string, password string, ip string) (time.Duration, error) {
+	user, err := u.d.GetUserByID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if err = verifyUserPassword(ctx, u.l, u.h, u.p, user, password, ip); err != nil {
+		return 0, err
+	}
+	if newEmail == user.Email || u.d.CheckEmailExist(ctx, newEmail) {
+		return 0, errcode.UserAlreadyExists
+	}
+	if err = u.ec.SavePending(ctx, userID, newEmail); err != nil {
+		return 0, fmt.Errorf("save pending email change of user %d err:%w", userID, err)
+	}
+	return u.e.SendCode(withUserLocale(ctx, user), model.CodePurposeChangeEmail, newEmail)
+}
+
+// ConfirmEmailChange 校验新邮箱收到的验证码后修改邮箱，修改成功后通知旧邮箱
+func (u *UserHandler) ConfirmEmailChange(ctx context.Context, userID uint64, newEmail string, code string, client model.ClientInfo) error {
+	pending, err := u.ec.Pending(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if pending != newEmail {
+		return errcode.EmailChangeNotRequested
+	}
+	if err = u.e.VerifyCode(ctx, model.CodePurposeChangeEmail, newEmail, code); err != nil {
+		return err
+	}
+	user, err := u.d.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	audit := model.EmailChange{NewEmail: newEmail, IP: client.IP, UserAgent: client.UserAgent}
+	err = u.writeUser(ctx, userID, func() error {
+		return u.d.ChangeEmail(ctx, userID, user.Email, audit)
+	})
+	if err != nil {
+		return err
+	}
+	if err = u.ec.ClearPending(ctx, userID); err != nil {
+		u.h.Warnf("clear pending email change of user %d error:%v", userID, err)
+	}
+	u.notify(ctx, user, model.MailKindSecurityAlert, map[string]string{
+		"Event":    model.SecurityEventEmailChanged,
+		"NewEmail": newEmail,
+	})
+	return nil
+}
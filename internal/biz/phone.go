@@ -0,0 +1,164 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	"github.com/TiktokCommence/userService/internal/model"
+	"time"
+)
+
+// ChangePhone 修改绑定的手机号，新号码需要重新通过短信验证，phone 为空表示解绑
+func (u *UserHandler) ChangePhone(ctx context.Context, userID uint64, phone string) error {
+	var number *string
+	if phone != "" {
+		normalized, err := u.s.NormalizePhone(phone)
+		if err != nil {
+			return err
+		}
+		number = &normalized
+	}
+	user, err := u.d.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if samePhone(user.Phone, number) {
+		return nil
+	}
+	return u.writeUser(ctx, userID, func() error {
+		return u.d.UpdatePhone(ctx, userID, number, nil)
+	})
+}
+
+// SendPhoneCode 向用户填写的手机号发送验证码，返回规范化后的号码与距离下一次可以发送的时长
+func (u *UserHandler) SendPhoneCode(ctx context.Context, userID uint64, phone string) (string, time.Duration, error) {
+	number, err := u.s.NormalizePhone(phone)
+	if err != nil {
+		return "", 0, err
+	}
+	user, err := u.d.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", 0, err
+	}
+	if err = u.checkPhoneOwner(ctx, userID, number); err != nil {
+		return "", 0, err
+	}
+	cooldown, err := u.s.SendCode(withUserLocale(ctx, user), model.CodePurposeVerifyPhone, number)
+	return number, cooldown, err
+}
+
+// VerifyPhone 校验短信验证码，通过后把号码绑定到用户并标记为已验证，返回规范化后的号码
+func (u *UserHandler) VerifyPhone(ctx context.Context, userID uint64, phone string, code string) (string, error) {
+	number, err := u.s.NormalizePhone(phone)
+	if err != nil {
+		return "", err
+	}
+	if err = u.s.VerifyCode(ctx, model.CodePurposeVerifyPhone, number, code); err != nil {
+		return "", err
+	}
+	// 号码是否已被其他用户验证由唯一索引判断，并发验证同一个号码时只有一个能成功
+	now := time.Now()
+	err = u.writeUser(ctx, userID, func() error {
+		return u.d.UpdatePhone(ctx, userID, &number, &now)
+	})
+	if err != nil {
+		return "", fmt.Errorf("verify phone of user %d err:%w", userID, err)
+	}
+	return number, nil
+}
+
+// SendPhoneLoginCode 向已验证的手机号发送登录验证码，号码未绑定时不做任何事情，避免暴露注册情况
+func (u *UserHandler) SendPhoneLoginCode(ctx context.Context, phone string) error {
+	number, err := u.s.NormalizePhone(phone)
+	if err != nil {
+		return err
+	}
+	user, err := u.d.GetUserByPhone(ctx, number)
+	if errors.Is(err, errcode.UserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, err = u.s.SendCode(withUserLocale(ctx, user), model.CodePurposeLogin, number)
+	return err
+}
+
+// VerifyPhoneLoginCode 使用短信验证码登录，失败次数与该用户的邮箱登录合并统计
+func (u *UserHandler) VerifyPhoneLoginCode(ctx context.Context, phone string, code string, ip string) (model.User, error) {
+	number, err := u.s.NormalizePhone(phone)
+	if err != nil {
+		return model.User{}, err
+	}
+	user, account, err := u.phoneAccount(ctx, number, ip)
+	if err != nil {
+		return model.User{}, err
+	}
+	if err = u.s.VerifyCode(ctx, model.CodePurposeLogin, number, code); err != nil {
+		return model.User{}, u.loginFailed(ctx, account, ip, err)
+	}
+	if user.ID == InvalidID {
+		return model.User{}, u.loginFailed(ctx, account, ip, errcode.UserNotFound)
+	}
+	return user, nil
+}
+
+// VerifyPasswordByPhone 使用已验证的手机号与密码登录
+func (u *UserHandler) VerifyPasswordByPhone(ctx context.Context, phone string, password string, ip string) (model.User, error) {
+	number, err := u.s.NormalizePhone(phone)
+	if err != nil {
+		return model.User{}, err
+	}
+	user, account, err := u.phoneAccount(ctx, number, ip)
+	if err != nil {
+		return model.User{}, err
+	}
+	if user.ID == InvalidID {
+		return model.User{}, u.loginFailed(ctx, account, ip, errcode.UserNotFound)
+	}
+	return u.VerifyPassword(ctx, user.Email, password, ip)
+}
+
+// phoneAccount 查出手机号对应的用户并检查登录锁定，返回统计失败次数使用的账号，
+// 号码没有绑定用户时以号码本身计数，避免通过锁定与否探测号码是否注册
+func (u *UserHandler) phoneAccount(ctx context.Context, phone string, ip string) (model.User, string, error) {
+	user, err := u.d.GetUserByPhone(ctx, phone)
+	if err != nil && !errors.Is(err, errcode.UserNotFound) {
+		return model.User{}, "", err
+	}
+	account := phone
+	if err == nil {
+		account = user.Email
+	}
+	retry, err := u.l.Locked(ctx, account, ip)
+	if err != nil {
+		return model.User{}, "", fmt.Errorf("check login lock of %s err:%w", account, err)
+	}
+	if retry > 0 {
+		return model.User{}, "", &errcode.LockedError{RetryAfter: retry}
+	}
+	return user, account, nil
+}
+
+// checkPhoneOwner 发送验证码前提前拒绝已被其他用户验证的号码，绑定时以唯一索引为准
+func (u *UserHandler) checkPhoneOwner(ctx context.Context, userID uint64, phone string) error {
+	owner, err := u.d.GetUserByPhone(ctx, phone)
+	if errors.Is(err, errcode.UserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if owner.ID != userID {
+		return errcode.PhoneAlreadyUsed
+	}
+	return nil
+}
+
+func samePhone(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
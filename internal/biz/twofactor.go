@@ -0,0 +1,266 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	"github.com/TiktokCommence/userService/internal/model"
+	"github.com/TiktokCommence/userService/internal/service"
+	"github.com/go-kratos/kratos/v2/log"
+	"time"
+	"unicode"
+)
+
+var _ service.TwoFactorHandler = (*TwoFactorHandler)(nil)
+
+type TwoFactorRepo interface {
+	GetTwoFactor(ctx context.Context, userID uint64) (model.TwoFactor, error)
+	SaveTwoFactor(ctx context.Context, tf model.TwoFactor) error
+	// UseStep 只有 step 大于上一次使用的时间片时才记录，并发使用同一个验证码时只有一个返回 true
+	UseStep(ctx context.Context, userID uint64, step int64) (bool, error)
+	// ReplaceRecoveryCodes 只有恢复码摘要仍为 old 时才替换为 new，并发使用同一个恢复码时只有一个返回 true
+	ReplaceRecoveryCodes(ctx context.Context, userID uint64, old, new string) (bool, error)
+	DeleteTwoFactor(ctx context.Context, userID uint64) error
+}
+
+type TOTPWorker interface {
+	// 返回新的密钥以及供验证器应用扫码的 otpauth URI
+	GenerateSecret(account string) (string, string, error)
+	// 校验通过时返回匹配的时间片
+	Validate(secret, code string) (int64, bool)
+	// 返回恢复码明文以及需要保存的摘要
+	GenerateRecoveryCodes() ([]string, []string, error)
+	HashRecoveryCode(code string) string
+}
+
+type ChallengeWorker interface {
+	CreateChallenge(ctx context.Context, userID uint64, client model.ClientInfo) (model.MFAChallenge, error)
+	GetChallenge(ctx context.Context, id string) (model.MFAChallenge, error)
+	ChallengeFailed(ctx context.Context, id string) error
+	ConsumeChallenge(ctx context.Context, id string) error
+}
+
+type TwoFactorHandler struct {
+	r TwoFactorRepo
+	t TOTPWorker
+	c ChallengeWorker
+	d DBWorker
+	p PasswordHasher
+	l LoginLimiter
+	h *log.Helper
+}
+
+func NewTwoFactorHandler(r TwoFactorRepo, t TOTPWorker, c ChallengeWorker, d DBWorker, p PasswordHasher, l LoginLimiter, logger log.Logger) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		r: r,
+		t: t,
+		c: c,
+		d: d,
+		p: p,
+		l: l,
+		h: log.NewHelper(logger),
+	}
+}
+
+// Enroll 校验当前密码后生成新的 TOTP 密钥，确认首个验证码之前不会启用，重复调用会替换未确认的密钥
+func (t *TwoFactorHandler) Enroll(ctx context.Context, userID uint64, password string, ip string) (string, string, error) {
+	user, err := t.d.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	if err = verifyUserPassword(ctx, t.l, t.h, t.p, user, password, ip); err != nil {
+		return "", "", err
+	}
+	tf, err := t.r.GetTwoFactor(ctx, userID)
+	if err != nil && !errors.Is(err, errcode.TwoFactorNotEnrolled) {
+		return "", "", err
+	}
+	if tf.Enabled {
+		return "", "", errcode.TwoFactorAlreadyEnabled
+	}
+	secret, uri, err := t.t.GenerateSecret(user.Email)
+	if err != nil {
+		return "", "", fmt.Errorf("generate totp secret err:%w", err)
+	}
+	if err = t.r.SaveTwoFactor(ctx, model.TwoFactor{UserID: userID, Secret: secret}); err != nil {
+		return "", "", err
+	}
+	return secret, uri, nil
+}
+
+// Confirm 校验当前密码与首个验证码后启用二次验证，返回只展示这一次的恢复码，验证码错误计入登录失败次数
+func (t *TwoFactorHandler) Confirm(ctx context.Context, userID uint64, password string, code string, ip string) ([]string, error) {
+	user, err := t.d.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifyUserPassword(ctx, t.l, t.h, t.p, user, password, ip); err != nil {
+		return nil, err
+	}
+	tf, err := t.r.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf.Enabled {
+		return nil, errcode.TwoFactorAlreadyEnabled
+	}
+	step, ok := t.t.Validate(tf.Secret, code)
+	if !ok {
+		return nil, recordLoginFailure(ctx, t.l, t.h, user.Email, ip, errcode.TwoFactorCodeInvalid)
+	}
+	codes, hashes, err := t.t.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("generate recovery codes err:%w", err)
+	}
+	now := time.Now()
+	tf.Enabled = true
+	tf.EnabledAt = &now
+	tf.LastUsedStep = step
+	tf.SetRecoveryCodeHashes(hashes)
+	if err = t.r.SaveTwoFactor(ctx, tf); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Disable 关闭二次验证，需要同时提供登录密码与验证码或恢复码，密码与验证码错误都计入登录失败次数
+func (t *TwoFactorHandler) Disable(ctx context.Context, userID uint64, password string, code string, ip string) error {
+	user, err := t.d.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err = verifyUserPassword(ctx, t.l, t.h, t.p, user, password, ip); err != nil {
+		return err
+	}
+	tf, err := t.r.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if tf.Enabled {
+		err = t.verifyFactor(ctx, &tf, code)
+		if errors.Is(err, errcode.TwoFactorCodeInvalid) {
+			return recordLoginFailure(ctx, t.l, t.h, user.Email, ip, err)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return t.r.DeleteTwoFactor(ctx, userID)
+}
+
+// BeginLogin 第一步校验通过后调用，用户启用了二次验证时创建登录挑战并返回挑战 ID，
+// 否则登录已经成功，清零账号的失败次数；启用二次验证时失败次数在 CompleteLogin 成功后才清零
+func (t *TwoFactorHandler) BeginLogin(ctx context.Context, user model.User, client model.ClientInfo) (string, bool, error) {
+	tf, err := t.r.GetTwoFactor(ctx, user.ID)
+	if err != nil && !errors.Is(err, errcode.TwoFactorNotEnrolled) {
+		return "", false, err
+	}
+	if err != nil || !tf.Enabled {
+		t.resetFailures(ctx, user)
+		return "", false, nil
+	}
+	challenge, err := t.c.CreateChallenge(ctx, user.ID, client)
+	if err != nil {
+		return "", false, err
+	}
+	return challenge.ID, true, nil
+}
+
+// CompleteLogin 校验登录挑战的验证码或恢复码，通过后返回待签发令牌的用户与发起登录的客户端。
+// 验证码错误计入账号与来源 IP 的登录失败次数，重新发起挑战不会清零，避免不断创建挑战来穷举验证码
+func (t *TwoFactorHandler) CompleteLogin(ctx context.Context, challengeID string, code string) (model.User, model.ClientInfo, error) {
+	challenge, err := t.c.GetChallenge(ctx, challengeID)
+	if err != nil {
+		return model.User{}, model.ClientInfo{}, err
+	}
+	user, err := t.d.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		return model.User{}, model.ClientInfo{}, err
+	}
+	ip := challenge.Client.IP
+	retry, err := t.l.Locked(ctx, user.Email, ip)
+	if err != nil {
+		return model.User{}, model.ClientInfo{}, fmt.Errorf("check login lock of %s err:%w", user.Email, err)
+	}
+	if retry > 0 {
+		return model.User{}, model.ClientInfo{}, &errcode.LockedError{RetryAfter: retry}
+	}
+	tf, err := t.r.GetTwoFactor(ctx, challenge.UserID)
+	if errors.Is(err, errcode.TwoFactorNotEnrolled) {
+		return model.User{}, model.ClientInfo{}, errcode.ChallengeInvalid
+	}
+	if err != nil {
+		return model.User{}, model.ClientInfo{}, err
+	}
+	if err = t.verifyFactor(ctx, &tf, code); err != nil {
+		if errors.Is(err, errcode.TwoFactorCodeInvalid) {
+			if err1 := t.c.ChallengeFailed(ctx, challengeID); err1 != nil {
+				t.h.Warnf("record failure of login challenge %s error:%v", challengeID, err1)
+			}
+			return model.User{}, model.ClientInfo{}, recordLoginFailure(ctx, t.l, t.h, user.Email, ip, err)
+		}
+		return model.User{}, model.ClientInfo{}, err
+	}
+	if err = t.c.ConsumeChallenge(ctx, challengeID); err != nil {
+		return model.User{}, model.ClientInfo{}, err
+	}
+	t.resetFailures(ctx, user)
+	return user, challenge.Client, nil
+}
+
+func (t *TwoFactorHandler) resetFailures(ctx context.Context, user model.User) {
+	if err := t.l.Reset(ctx, user.Email); err != nil {
+		t.h.Warnf("reset login failures of user %d error:%v", user.ID, err)
+	}
+}
+
+// verifyFactor 纯数字按 TOTP 验证码校验，否则按恢复码校验，恢复码使用后立即作废。
+// 时间片与恢复码都以条件更新的方式消费，同一个验证码或恢复码并发提交时只有一个能通过
+func (t *TwoFactorHandler) verifyFactor(ctx context.Context, tf *model.TwoFactor, code string) error {
+	if isDigits(code) {
+		step, ok := t.t.Validate(tf.Secret, code)
+		if !ok || step <= tf.LastUsedStep {
+			return errcode.TwoFactorCodeInvalid
+		}
+		used, err := t.r.UseStep(ctx, tf.UserID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return errcode.TwoFactorCodeInvalid
+		}
+		tf.LastUsedStep = step
+		return nil
+	}
+	hash := t.t.HashRecoveryCode(code)
+	hashes := tf.RecoveryCodeHashes()
+	for i, h := range hashes {
+		if h != hash {
+			continue
+		}
+		old := tf.RecoveryCodes
+		tf.SetRecoveryCodeHashes(append(hashes[:i], hashes[i+1:]...))
+		replaced, err := t.r.ReplaceRecoveryCodes(ctx, tf.UserID, old, tf.RecoveryCodes)
+		if err != nil {
+			return err
+		}
+		if !replaced {
+			return errcode.TwoFactorCodeInvalid
+		}
+		return nil
+	}
+	return errcode.TwoFactorCodeInvalid
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
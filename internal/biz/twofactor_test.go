@@ -0,0 +1,259 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	"github.com/TiktokCommence/userService/internal/model"
+	"github.com/go-kratos/kratos/v2/log"
+	"sync"
+	"testing"
+)
+
+// fakeTwoFactorRepo 测试使用的二次验证存储，时间片与恢复码按条件更新
+type fakeTwoFactorRepo struct {
+	mu sync.Mutex
+	m  map[uint64]model.TwoFactor
+}
+
+func newFakeTwoFactorRepo() *fakeTwoFactorRepo {
+	return &fakeTwoFactorRepo{m: make(map[uint64]model.TwoFactor)}
+}
+
+func (r *fakeTwoFactorRepo) GetTwoFactor(ctx context.Context, userID uint64) (model.TwoFactor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tf, ok := r.m[userID]
+	if !ok {
+		return model.TwoFactor{}, errcode.TwoFactorNotEnrolled
+	}
+	return tf, nil
+}
+
+func (r *fakeTwoFactorRepo) SaveTwoFactor(ctx context.Context, tf model.TwoFactor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.m[tf.UserID] = tf
+	return nil
+}
+
+func (r *fakeTwoFactorRepo) UseStep(ctx context.Context, userID uint64, step int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tf := r.m[userID]
+	if step <= tf.LastUsedStep {
+		return false, nil
+	}
+	tf.LastUsedStep = step
+	r.m[userID] = tf
+	return true, nil
+}
+
+func (r *fakeTwoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, userID uint64, old, new string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tf := r.m[userID]
+	if tf.RecoveryCodes != old {
+		return false, nil
+	}
+	tf.RecoveryCodes = new
+	r.m[userID] = tf
+	return true, nil
+}
+
+func (r *fakeTwoFactorRepo) DeleteTwoFactor(ctx context.Context, userID uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.m, userID)
+	return nil
+}
+
+// fakeTOTP steps 中的验证码对应其时间片，其余验证码都不正确
+type fakeTOTP struct {
+	steps map[string]int64
+}
+
+func (f fakeTOTP) GenerateSecret(account string) (string, string, error) {
+	return "secret", "otpauth://totp/" + account, nil
+}
+
+func (f fakeTOTP) Validate(secret, code string) (int64, bool) {
+	step, ok := f.steps[code]
+	return step, ok
+}
+
+func (f fakeTOTP) GenerateRecoveryCodes() ([]string, []string, error) {
+	codes := []string{"recovery-a", "recovery-b"}
+	return codes, []string{f.HashRecoveryCode(codes[0]), f.HashRecoveryCode(codes[1])}, nil
+}
+
+func (f fakeTOTP) HashRecoveryCode(code string) string {
+	return "h:" + code
+}
+
+// fakeChallenges 测试使用的登录挑战，记录每个挑战失败的次数
+type fakeChallenges struct {
+	mu         sync.Mutex
+	n          int
+	challenges map[string]model.MFAChallenge
+	failures   map[string]int
+}
+
+func newFakeChallenges() *fakeChallenges {
+	return &fakeChallenges{challenges: make(map[string]model.MFAChallenge), failures: make(map[string]int)}
+}
+
+func (f *fakeChallenges) CreateChallenge(ctx context.Context, userID uint64, client model.ClientInfo) (model.MFAChallenge, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.n++
+	c := model.MFAChallenge{ID: fmt.Sprintf("challenge-%d", f.n), UserID: userID, Client: client}
+	f.challenges[c.ID] = c
+	return c, nil
+}
+
+func (f *fakeChallenges) GetChallenge(ctx context.Context, id string) (model.MFAChallenge, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, ok := f.challenges[id]
+	if !ok {
+		return model.MFAChallenge{}, errcode.ChallengeInvalid
+	}
+	return c, nil
+}
+
+func (f *fakeChallenges) ChallengeFailed(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[id]++
+	return nil
+}
+
+func (f *fakeChallenges) ConsumeChallenge(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.challenges, id)
+	return nil
+}
+
+func TestTwoFactorHandler_Flow(t *testing.T) {
+	ctx := context.Background()
+	const email = "a@example.com"
+	d := newFakeDB(
+		model.User{ID: 1, Email: email, Password: "hashed:secret"},
+		model.User{ID: 2, Email: "b@example.com", Password: "hashed:secret"},
+	)
+	l := newFakeLimiter(10)
+	challenges := newFakeChallenges()
+	totp := fakeTOTP{steps: map[string]int64{"111111": 10, "222222": 11, "333333": 12}}
+	h := NewTwoFactorHandler(newFakeTwoFactorRepo(), totp, challenges, d, fakeHasher{}, l, log.DefaultLogger)
+	client := model.ClientInfo{IP: "1.1.1.1"}
+
+	// 启用需要当前密码与首个验证码
+	if _, _, err := h.Enroll(ctx, 1, "x", client.IP); !errors.Is(err, errcode.PasswordIncorrect) {
+		t.Fatalf("expected enroll with wrong password to fail, got %v", err)
+	}
+	if _, _, err := h.Enroll(ctx, 1, "secret", client.IP); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Confirm(ctx, 1, "secret", "000000", client.IP); !errors.Is(err, errcode.TwoFactorCodeInvalid) {
+		t.Fatalf("expected confirm with wrong code to fail, got %v", err)
+	}
+	codes, err := h.Confirm(ctx, 1, "secret", "111111", client.IP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 2 {
+		t.Fatalf("expected 2 recovery codes, got %v", codes)
+	}
+	if l.failures[email] != 2 {
+		t.Errorf("expected wrong password and code to be counted, got %d failures", l.failures[email])
+	}
+
+	// 没有启用二次验证的用户直接登录成功
+	if id, required, err := h.BeginLogin(ctx, model.User{ID: 2, Email: "b@example.com"}, client); err != nil || required || id != "" {
+		t.Fatalf("expected no challenge for user without two factor, got %q %v %v", id, required, err)
+	}
+
+	begin := func() string {
+		id, required, err := h.BeginLogin(ctx, model.User{ID: 1, Email: email}, client)
+		if err != nil || !required {
+			t.Fatalf("expected a challenge, got %v %v", required, err)
+		}
+		return id
+	}
+	current := begin()
+	cases := []struct {
+		name string
+		// 为 true 时重新发起挑战
+		fresh  bool
+		code   string
+		expect error
+	}{
+		{name: "wrong code", code: "000000", expect: errcode.TwoFactorCodeInvalid},
+		// 启用时已经使用过的时间片不能重放
+		{name: "replayed step", code: "111111", expect: errcode.TwoFactorCodeInvalid},
+		{name: "totp", code: "222222"},
+		// 挑战通过后作废
+		{name: "consumed challenge", code: "333333", expect: errcode.ChallengeInvalid},
+		{name: "same step", fresh: true, code: "222222", expect: errcode.TwoFactorCodeInvalid},
+		{name: "recovery code", code: "recovery-a"},
+		// 恢复码只能使用一次
+		{name: "used recovery code", fresh: true, code: "recovery-a", expect: errcode.TwoFactorCodeInvalid},
+		{name: "unknown recovery code", code: "recovery-x", expect: errcode.TwoFactorCodeInvalid},
+		{name: "other recovery code", code: "recovery-b"},
+	}
+	for _, c := range cases {
+		if c.fresh {
+			current = begin()
+		}
+		user, gotClient, err := h.CompleteLogin(ctx, current, c.code)
+		if !errors.Is(err, c.expect) {
+			t.Fatalf("%s: expected %v, got %v", c.name, c.expect, err)
+		}
+		if c.expect == nil && (user.ID != 1 || gotClient != client) {
+			t.Errorf("%s: expected user 1 from %+v, got %+v %+v", c.name, client, user, gotClient)
+		}
+	}
+	if challenges.failures["challenge-1"] != 2 {
+		t.Errorf("expected 2 failures on the first challenge, got %d", challenges.failures["challenge-1"])
+	}
+	// 登录成功后清零失败次数
+	if l.failures[email] != 0 {
+		t.Errorf("expected failures to be reset after login, got %d", l.failures[email])
+	}
+}
+
+func TestTwoFactorHandler_CompleteLoginLockout(t *testing.T) {
+	ctx := context.Background()
+	d := newFakeDB(model.User{ID: 1, Email: "a@example.com", Password: "hashed:secret"})
+	l := newFakeLimiter(3)
+	repo := newFakeTwoFactorRepo()
+	totp := fakeTOTP{steps: map[string]int64{"111111": 10}}
+	h := NewTwoFactorHandler(repo, totp, newFakeChallenges(), d, fakeHasher{}, l, log.DefaultLogger)
+	if err := repo.SaveTwoFactor(ctx, model.TwoFactor{UserID: 1, Secret: "secret", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 每次失败都重新发起挑战，失败次数依然按账号累计
+	cases := []struct {
+		code   string
+		expect error
+	}{
+		{"000000", errcode.TwoFactorCodeInvalid},
+		{"000000", errcode.TwoFactorCodeInvalid},
+		{"000000", errcode.AccountLocked},
+		// 锁定期间正确的验证码同样被拒绝
+		{"111111", errcode.AccountLocked},
+	}
+	for i, c := range cases {
+		id, _, err := h.BeginLogin(ctx, model.User{ID: 1, Email: "a@example.com"}, model.ClientInfo{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err = h.CompleteLogin(ctx, id, c.code); !errors.Is(err, c.expect) {
+			t.Fatalf("attempt %d: expected %v, got %v", i+1, c.expect, err)
+		}
+	}
+}
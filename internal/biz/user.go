@@ -8,6 +8,8 @@ import (
 	"github.com/TiktokCommence/userService/internal/model"
 	"github.com/TiktokCommence/userService/internal/service"
 	"github.com/go-kratos/kratos/v2/log"
+	"golang.org/x/sync/singleflight"
+	"strconv"
 	"time"
 )
 
@@ -21,9 +23,49 @@ type GenerateID interface {
 	GenerateUserID(ctx context.Context) (uint64, error)
 }
 
+// 验证码按用途隔离，purpose 取值见 model.CodePurposeXXX
 type EmailWorker interface {
-	VerifyEmailCode(ctx context.Context, email, code string) bool
-	SendEmailCode(ctx context.Context, email string) (string, error)
+	VerifyCode(ctx context.Context, purpose, email, code string) error
+	SendCode(ctx context.Context, purpose, email string) (time.Duration, error)
+	PeekCode(ctx context.Context, purpose, email string) (string, error)
+	// kind 取值见 model.MailKindXXX
+	SendNotice(ctx context.Context, kind, email string, data map[string]string) error
+}
+
+// 短信验证码与邮箱验证码共用用途划分，phone 为 E.164 格式
+type SmsWorker interface {
+	NormalizePhone(phone string) (string, error)
+	SendCode(ctx context.Context, purpose, phone string) (time.Duration, error)
+	VerifyCode(ctx context.Context, purpose, phone, code string) error
+}
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password, encoded string) (bool, error)
+	NeedsRehash(encoded string) bool
+}
+
+type LoginLimiter interface {
+	Locked(ctx context.Context, account, ip string) (time.Duration, error)
+	RecordFailure(ctx context.Context, account, ip string) (time.Duration, error)
+	Reset(ctx context.Context, account string) error
+	Unlock(ctx context.Context, account, ip string) error
+}
+
+type EmailCanonicalizer interface {
+	Canonicalize(email string) (string, error)
+}
+
+// LoadLock 缓存未命中时多个实例之间的回源锁，同一个用户同一时刻只有一个实例查询数据库
+type LoadLock interface {
+	// TryLock 没有抢到锁时返回 false，未开启分布式锁时总是返回 true
+	TryLock(ctx context.Context, id uint64) (unlock func(), ok bool, err error)
+	// Wait 等待其他实例释放锁，超时返回错误
+	Wait(ctx context.Context, id uint64) error
+}
+
+type PasswordPolicy interface {
+	Check(password, email string) []model.PasswordViolation
 }
 
 type RedisWorker interface {
@@ -37,31 +79,65 @@ type RedisWorker interface {
 type DBWorker interface {
 	CreateUser(ctx context.Context, user model.User) error
 	GetUserByID(ctx context.Context, id uint64) (model.User, error)
-	SaveUserInfo(ctx context.Context, user model.User) error
+	// 用户不存在时返回 errcode.UserNotFound
+	UpdateProfile(ctx context.Context, id uint64, p model.ProfileUpdate) error
+	// 只有当前的密码哈希仍是 old 时才修改，返回是否修改成功
+	UpdatePassword(ctx context.Context, id uint64, old, hashed string) (bool, error)
 	CheckEmailExist(ctx context.Context, email string) bool
 	GetUserByEmail(ctx context.Context, email string) (model.User, error)
+	// 只返回手机号已验证的用户
+	GetUserByPhone(ctx context.Context, phone string) (model.User, error)
+	// 号码已被其他用户验证时返回 errcode.PhoneAlreadyUsed
+	UpdatePhone(ctx context.Context, id uint64, phone *string, verifiedAt *time.Time) error
+	// 邮箱被占用时返回 errcode.UserAlreadyExists，audit 中的 UserID、OldEmail 由实现填写
+	ChangeEmail(ctx context.Context, id uint64, oldEmail string, audit model.EmailChange) error
 	DeleteUser(ctx context.Context, id uint64) error
 }
 
 type UserHandler struct {
-	g GenerateID
-	r RedisWorker
-	d DBWorker
-	e EmailWorker
-	h *log.Helper
+	g  GenerateID
+	r  RedisWorker
+	d  DBWorker
+	e  EmailWorker
+	s  SmsWorker
+	p  PasswordHasher
+	pp PasswordPolicy
+	l  LoginLimiter
+	ec EmailChangeWorker
+	c  EmailCanonicalizer
+	ll LoadLock
+	h  *log.Helper
+	// 合并同一个实例中对同一个用户的回源查询
+	sf singleflight.Group
 }
 
-func NewUserHandler(g GenerateID, r RedisWorker, d DBWorker, e EmailWorker, logger log.Logger) *UserHandler {
+func NewUserHandler(g GenerateID, r RedisWorker, d DBWorker, e EmailWorker, s SmsWorker, p PasswordHasher, pp PasswordPolicy, l LoginLimiter, ec EmailChangeWorker, c EmailCanonicalizer, ll LoadLock, logger log.Logger) *UserHandler {
 	return &UserHandler{
-		g: g,
-		r: r,
-		d: d,
-		e: e,
-		h: log.NewHelper(logger),
+		g:  g,
+		r:  r,
+		d:  d,
+		e:  e,
+		s:  s,
+		p:  p,
+		pp: pp,
+		l:  l,
+		ec: ec,
+		c:  c,
+		ll: ll,
+		h:  log.NewHelper(logger),
 	}
 }
 
+// CanonicalEmail 返回邮箱的规范形式，验证码、登录锁定等以邮箱为键的数据都使用规范形式
+func (u *UserHandler) CanonicalEmail(email string) (string, error) {
+	return u.c.Canonicalize(email)
+}
+
 func (u *UserHandler) CreateUser(ctx context.Context, email string, password string) (uint64, error) {
+	hashed, err := u.p.Hash(password)
+	if err != nil {
+		return InvalidID, fmt.Errorf("hash password err:%w", err)
+	}
 	id, err := u.g.GenerateUserID(ctx)
 	if err != nil {
 		return InvalidID, err
@@ -69,52 +145,113 @@ func (u *UserHandler) CreateUser(ctx context.Context, email string, password str
 	user := model.User{
 		ID:       id,
 		Email:    email,
-		Password: password,
+		Password: hashed,
 	}
 	err = u.d.CreateUser(ctx, user)
 	if err != nil {
 		return InvalidID, err
 	}
+	u.notify(ctx, user, model.MailKindWelcome, nil)
 	return id, nil
 }
 
-func (u *UserHandler) VerifyCode(ctx context.Context, email string, code string) bool {
-	return u.e.VerifyEmailCode(ctx, email, code)
+func (u *UserHandler) VerifyCode(ctx context.Context, email string, code string) error {
+	return u.e.VerifyCode(ctx, model.CodePurposeRegister, email, code)
 }
 
-func (u *UserHandler) SendVerifyCode(ctx context.Context, email string) (string, error) {
-
-	return u.e.SendEmailCode(ctx, email)
+// SendVerifyCode 发送注册验证码，返回距离下一次可以发送的时长
+func (u *UserHandler) SendVerifyCode(ctx context.Context, email string) (time.Duration, error) {
+	return u.e.SendCode(ctx, model.CodePurposeRegister, email)
 }
 
+// GetUserInfoByID 优先读缓存，缓存未命中时同一个实例中对同一个用户的查询合并为一次回源，
+// 开启回源锁时多个实例之间也只有一个查询数据库，其余等待其写入缓存后重新读取
 func (u *UserHandler) GetUserInfoByID(ctx context.Context, userID uint64) (model.User, error) {
-	user, err := u.r.GetUserByID(ctx, userID)
-	if err != nil && !errors.Is(err, errcode.CacheMiss) && !errors.Is(err, errcode.CacheNullValue) {
-		return model.User{}, err
+	user, err := u.cachedUser(ctx, userID)
+	if !errors.Is(err, errcode.CacheMiss) {
+		return user, err
 	}
+	// 回源结果由所有等待的调用共享，不能因为第一个调用方取消而失败
+	ch := u.sf.DoChan(strconv.FormatUint(userID, 10), func() (interface{}, error) {
+		return u.loadUser(context.WithoutCancel(ctx), userID)
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return model.User{}, res.Err
+		}
+		return res.Val.(model.User), nil
+	case <-ctx.Done():
+		return model.User{}, ctx.Err()
+	}
+}
+
+// cachedUser 读取缓存，缓存的空值表示用户不存在，返回 errcode.UserNotFound
+func (u *UserHandler) cachedUser(ctx context.Context, userID uint64) (model.User, error) {
+	user, err := u.r.GetUserByID(ctx, userID)
 	if errors.Is(err, errcode.CacheNullValue) {
-		return model.User{}, nil
+		return model.User{}, errcode.UserNotFound
 	}
-	if errors.Is(err, errcode.CacheMiss) {
-		user, err = u.d.GetUserByID(ctx, userID)
-		if err != nil && !errors.Is(err, errcode.UserNotFound) {
-			return model.User{}, fmt.Errorf("get user %d from db err:%w", userID, err)
+	if err != nil && !errors.Is(err, errcode.CacheMiss) {
+		return model.User{}, fmt.Errorf("get user %d from cache err:%w", userID, err)
+	}
+	return user, err
+}
+
+// loadUser 从数据库读取用户并写入缓存，没有抢到回源锁时先等待持有锁的实例写入缓存，
+// 等待超时或锁释放后缓存中仍然没有时自己查询数据库
+func (u *UserHandler) loadUser(ctx context.Context, userID uint64) (model.User, error) {
+	unlock, ok, err := u.ll.TryLock(ctx, userID)
+	if err != nil {
+		u.h.Warnf("lock loading user %d error:%v", userID, err)
+	}
+	if ok {
+		defer unlock()
+	} else if err == nil {
+		if err = u.ll.Wait(ctx, userID); err != nil {
+			u.h.Warnf("wait for loading user %d error:%v", userID, err)
 		}
-		if errors.Is(err, errcode.UserNotFound) {
-			err1 := u.r.SetNULLUser(ctx, userID)
-			return model.User{}, fmt.Errorf("user %d not found and set null user err:%w", userID, err1)
+		user, err := u.cachedUser(ctx, userID)
+		if !errors.Is(err, errcode.CacheMiss) {
+			return user, err
 		}
-		err = u.r.SetUser(ctx, user)
-		if err != nil {
-			return user, fmt.Errorf("get user %d from db success but set user err:%w", userID, err)
+	}
+	user, err := u.d.GetUserByID(ctx, userID)
+	if errors.Is(err, errcode.UserNotFound) {
+		if err1 := u.r.SetNULLUser(ctx, userID); err1 != nil {
+			u.h.Warnf("set null user %d error:%v", userID, err1)
 		}
-		return user, nil
+		return model.User{}, err
+	}
+	if err != nil {
+		return model.User{}, fmt.Errorf("get user %d from db err:%w", userID, err)
+	}
+	if err = u.r.SetUser(ctx, user); err != nil {
+		u.h.Warnf("get user %d from db success but set user error:%v", userID, err)
 	}
 	return user, nil
 }
 
-func (u *UserHandler) UpdateUserInfo(ctx context.Context, user model.User) error {
-	id := user.ID
+// UpdateProfile 只修改请求中设置了的资料字段
+func (u *UserHandler) UpdateProfile(ctx context.Context, userID uint64, p model.ProfileUpdate) error {
+	return u.writeUser(ctx, userID, func() error {
+		return u.d.UpdateProfile(ctx, userID, p)
+	})
+}
+
+// savePassword 以读到的旧哈希为条件修改密码，期间密码被其他流程修改时返回 false
+func (u *UserHandler) savePassword(ctx context.Context, user model.User, hashed string) (bool, error) {
+	var ok bool
+	err := u.writeUser(ctx, user.ID, func() error {
+		var err error
+		ok, err = u.d.UpdatePassword(ctx, user.ID, user.Password, hashed)
+		return err
+	})
+	return ok, err
+}
+
+// writeUser 在写库前禁用读流程写缓存并删除缓存，写库后延迟恢复，保证缓存与数据库一致
+func (u *UserHandler) writeUser(ctx context.Context, id uint64, write func() error) error {
 	// 1 针对 key 维度禁用读流程写缓存机制
 	if err := u.r.DisableRead(ctx, id); err != nil {
 		return fmt.Errorf("disable cache read userID %d error:%w", id, err)
@@ -134,11 +271,7 @@ func (u *UserHandler) UpdateUserInfo(ctx context.Context, user model.User) error
 		return fmt.Errorf("delete user %d error:%w", id, err)
 	}
 	// 3 数据写入 db
-	err := u.d.SaveUserInfo(ctx, user)
-	if err != nil {
-		return err
-	}
-	return nil
+	return write()
 }
 
 func (u *UserHandler) CheckEmailExist(ctx context.Context, email string) bool {
@@ -148,8 +281,205 @@ func (u *UserHandler) CheckEmailExist(ctx context.Context, email string) bool {
 func (u *UserHandler) GetUserInfoByEmail(ctx context.Context, email string) (model.User, error) {
 	return u.d.GetUserByEmail(ctx, email)
 }
-func (u *UserHandler) Logout(ctx context.Context, userID uint64) error {
-	return u.r.DeleteUser(ctx, userID)
+
+// VerifyPassword 校验登录密码，账号或来源 IP 失败次数过多时返回 *errcode.LockedError，存量的明文或旧参数哈希在校验通过后会被重新计算
+func (u *UserHandler) VerifyPassword(ctx context.Context, email string, password string, ip string) (model.User, error) {
+	retry, err := u.l.Locked(ctx, email, ip)
+	if err != nil {
+		return model.User{}, fmt.Errorf("check login lock of %s err:%w", email, err)
+	}
+	if retry > 0 {
+		return model.User{}, &errcode.LockedError{RetryAfter: retry}
+	}
+	user, err := u.d.GetUserByEmail(ctx, email)
+	if errors.Is(err, errcode.UserNotFound) {
+		// 不存在的账号同样计入失败次数，避免通过锁定与否探测账号是否注册
+		return model.User{}, u.loginFailed(ctx, email, ip, err)
+	}
+	if err != nil {
+		return model.User{}, err
+	}
+	ok, err := u.p.Verify(password, user.Password)
+	if err != nil {
+		return model.User{}, fmt.Errorf("verify password of user %d err:%w", user.ID, err)
+	}
+	if !ok {
+		return model.User{}, u.loginFailed(ctx, email, ip, errcode.PasswordIncorrect)
+	}
+	if u.p.NeedsRehash(user.Password) {
+		u.rehashPassword(ctx, user, password)
+	}
+	return user, nil
+}
+
+// PeekVerifyCode 读取尚未使用的验证码，仅供开发、测试环境的调试接口使用
+func (u *UserHandler) PeekVerifyCode(ctx context.Context, purpose string, email string) (string, error) {
+	return u.e.PeekCode(ctx, purpose, email)
+}
+
+// SendLoginCode 向邮箱发送登录验证码，邮箱未注册时不做任何事情，避免暴露注册情况
+func (u *UserHandler) SendLoginCode(ctx context.Context, email string) error {
+	user, err := u.d.GetUserByEmail(ctx, email)
+	if errors.Is(err, errcode.UserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, err = u.e.SendCode(withUserLocale(ctx, user), model.CodePurposeLogin, email)
+	return err
+}
+
+// VerifyLoginCode 使用邮箱验证码登录，与密码登录共用失败次数统计与锁定
+func (u *UserHandler) VerifyLoginCode(ctx context.Context, email string, code string, ip string) (model.User, error) {
+	retry, err := u.l.Locked(ctx, email, ip)
+	if err != nil {
+		return model.User{}, fmt.Errorf("check login lock of %s err:%w", email, err)
+	}
+	if retry > 0 {
+		return model.User{}, &errcode.LockedError{RetryAfter: retry}
+	}
+	if err = u.e.VerifyCode(ctx, model.CodePurposeLogin, email, code); err != nil {
+		return model.User{}, u.loginFailed(ctx, email, ip, err)
+	}
+	return u.d.GetUserByEmail(ctx, email)
+}
+
+// UnlockAccount 解除账号及 IP 的登录锁定
+func (u *UserHandler) UnlockAccount(ctx context.Context, email string, ip string) error {
+	return u.l.Unlock(ctx, email, ip)
+}
+
+// loginFailed 记录登录失败，本次失败触发锁定时返回 *errcode.LockedError，否则返回 cause
+func (u *UserHandler) loginFailed(ctx context.Context, email, ip string, cause error) error {
+	return recordLoginFailure(ctx, u.l, u.h, email, ip, cause)
+}
+
+// recordLoginFailure 记录一次失败，达到阈值时返回 *errcode.LockedError，否则返回 cause
+func recordLoginFailure(ctx context.Context, l LoginLimiter, h *log.Helper, account, ip string, cause error) error {
+	retry, err := l.RecordFailure(ctx, account, ip)
+	if err != nil {
+		h.Warnf("record login failure of %s error:%v", account, err)
+		return cause
+	}
+	if retry > 0 {
+		return &errcode.LockedError{RetryAfter: retry}
+	}
+	return cause
+}
+
+// verifyUserPassword 校验已登录用户的密码，与登录共用失败次数统计与锁定，避免持有会话的人穷举密码。
+// 校验通过不清零失败次数，失败次数只在登录成功后清零
+func verifyUserPassword(ctx context.Context, l LoginLimiter, h *log.Helper, p PasswordHasher, user model.User, password, ip string) error {
+	retry, err := l.Locked(ctx, user.Email, ip)
+	if err != nil {
+		return fmt.Errorf("check login lock of %s err:%w", user.Email, err)
+	}
+	if retry > 0 {
+		return &errcode.LockedError{RetryAfter: retry}
+	}
+	ok, err := p.Verify(password, user.Password)
+	if err != nil {
+		return fmt.Errorf("verify password of user %d err:%w", user.ID, err)
+	}
+	if !ok {
+		return recordLoginFailure(ctx, l, h, user.Email, ip, errcode.PasswordIncorrect)
+	}
+	return nil
+}
+
+// SendResetCode 向邮箱发送重置密码验证码，邮箱未注册时不做任何事情，避免暴露注册情况
+func (u *UserHandler) SendResetCode(ctx context.Context, email string) error {
+	user, err := u.d.GetUserByEmail(ctx, email)
+	if errors.Is(err, errcode.UserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, err = u.e.SendCode(withUserLocale(ctx, user), model.CodePurposeReset, email)
+	return err
+}
+
+// ResetPassword 校验重置密码验证码后修改密码，返回被重置密码的用户 ID
+func (u *UserHandler) ResetPassword(ctx context.Context, email string, code string, password string) (uint64, error) {
+	if err := u.e.VerifyCode(ctx, model.CodePurposeReset, email, code); err != nil {
+		return InvalidID, err
+	}
+	user, err := u.d.GetUserByEmail(ctx, email)
+	if err != nil {
+		return InvalidID, err
+	}
+	hashed, err := u.p.Hash(password)
+	if err != nil {
+		return InvalidID, fmt.Errorf("hash password err:%w", err)
+	}
+	ok, err := u.savePassword(ctx, user, hashed)
+	if err != nil {
+		return InvalidID, fmt.Errorf("reset password of user %d err:%w", user.ID, err)
+	}
+	if !ok {
+		return InvalidID, fmt.Errorf("reset password of user %d err:password changed concurrently", user.ID)
+	}
+	u.notify(ctx, user, model.MailKindSecurityAlert, map[string]string{"Event": model.SecurityEventPasswordReset})
+	return user.ID, nil
+}
+
+// CheckPasswordPolicy 返回密码不满足的策略规则，email 用于检查密码是否与邮箱相似
+func (u *UserHandler) CheckPasswordPolicy(email string, password string) []model.PasswordViolation {
+	return u.pp.Check(password, email)
+}
+
+// ChangePassword 校验旧密码后修改密码，缓存通过与 UpdateProfile 相同的禁用、删除流程失效。
+// 校验之后密码被其他流程修改时返回 errcode.PasswordIncorrect
+func (u *UserHandler) ChangePassword(ctx context.Context, userID uint64, oldPassword string, newPassword string, ip string) error {
+	user, err := u.d.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err = verifyUserPassword(ctx, u.l, u.h, u.p, user, oldPassword, ip); err != nil {
+		return err
+	}
+	hashed, err := u.p.Hash(newPassword)
+	if err != nil {
+		return fmt.Errorf("hash password err:%w", err)
+	}
+	ok, err := u.savePassword(ctx, user, hashed)
+	if err != nil {
+		return fmt.Errorf("change password of user %d err:%w", userID, err)
+	}
+	if !ok {
+		return errcode.PasswordIncorrect
+	}
+	u.notify(ctx, user, model.MailKindSecurityAlert, map[string]string{"Event": model.SecurityEventPasswordChanged})
+	return nil
+}
+
+// notify 发送通知邮件，发送失败只记录日志，不影响已经完成的操作
+func (u *UserHandler) notify(ctx context.Context, user model.User, kind string, data map[string]string) {
+	if err := u.e.SendNotice(withUserLocale(ctx, user), kind, user.Email, data); err != nil {
+		u.h.Warnf("send %s mail to user %d error:%v", kind, user.ID, err)
+	}
+}
+
+// withUserLocale 用户设置了偏好语言时覆盖请求中的语言
+func withUserLocale(ctx context.Context, user model.User) context.Context {
+	if user.Locale != nil && *user.Locale != "" {
+		return model.WithLocale(ctx, *user.Locale)
+	}
+	return ctx
+}
+
+// rehashPassword 密码在此期间被修改时放弃，不会用旧密码覆盖新密码
+func (u *UserHandler) rehashPassword(ctx context.Context, user model.User, password string) {
+	hashed, err := u.p.Hash(password)
+	if err != nil {
+		u.h.Warnf("rehash password of user %d error:%v", user.ID, err)
+		return
+	}
+	if _, err = u.savePassword(ctx, user, hashed); err != nil {
+		u.h.Warnf("save rehashed password of user %d error:%v", user.ID, err)
+	}
 }
 
 func (u *UserHandler) DeleteUser(ctx context.Context, userID uint64) error {
@@ -0,0 +1,246 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	"github.com/TiktokCommence/userService/internal/model"
+	"github.com/go-kratos/kratos/v2/log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDB 测试使用的用户表，只实现登录、二次验证用到的方法
+type fakeDB struct {
+	DBWorker
+	mu    sync.Mutex
+	users map[uint64]model.User
+}
+
+func newFakeDB(users ...model.User) *fakeDB {
+	d := &fakeDB{users: make(map[uint64]model.User)}
+	for _, u := range users {
+		d.users[u.ID] = u
+	}
+	return d
+}
+
+func (d *fakeDB) GetUserByID(ctx context.Context, id uint64) (model.User, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	u, ok := d.users[id]
+	if !ok {
+		return model.User{}, errcode.UserNotFound
+	}
+	return u, nil
+}
+
+func (d *fakeDB) GetUserByEmail(ctx context.Context, email string) (model.User, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, u := range d.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return model.User{}, errcode.UserNotFound
+}
+
+func (d *fakeDB) UpdatePassword(ctx context.Context, id uint64, old, hashed string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	u, ok := d.users[id]
+	if !ok || u.Password != old {
+		return false, nil
+	}
+	u.Password = hashed
+	d.users[id] = u
+	return true, nil
+}
+
+// fakeRedis 写库流程用到的缓存操作都直接成功
+type fakeRedis struct {
+	RedisWorker
+}
+
+func (fakeRedis) DeleteUser(ctx context.Context, id uint64) error  { return nil }
+func (fakeRedis) EnableRead(ctx context.Context, id uint64) error  { return nil }
+func (fakeRedis) DisableRead(ctx context.Context, id uint64) error { return nil }
+
+// fakeHasher 哈希为 "hashed:" 加明文，没有前缀的存量密码按明文比较并需要重新计算
+type fakeHasher struct{}
+
+func (fakeHasher) Hash(password string) (string, error) {
+	return "hashed:" + password, nil
+}
+
+func (fakeHasher) Verify(password, encoded string) (bool, error) {
+	if strings.HasPrefix(encoded, "hashed:") {
+		return encoded == "hashed:"+password, nil
+	}
+	return encoded == password, nil
+}
+
+func (fakeHasher) NeedsRehash(encoded string) bool {
+	return !strings.HasPrefix(encoded, "hashed:")
+}
+
+// fakeLimiter 按账号统计失败次数，达到 threshold 时锁定，每次锁定的时长翻倍
+type fakeLimiter struct {
+	mu        sync.Mutex
+	threshold int
+	base      time.Duration
+	failures  map[string]int
+	levels    map[string]int
+	locked    map[string]time.Duration
+}
+
+func newFakeLimiter(threshold int) *fakeLimiter {
+	return &fakeLimiter{
+		threshold: threshold,
+		base:      time.Minute,
+		failures:  make(map[string]int),
+		levels:    make(map[string]int),
+		locked:    make(map[string]time.Duration),
+	}
+}
+
+func (l *fakeLimiter) Locked(ctx context.Context, account, ip string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.locked[account], nil
+}
+
+func (l *fakeLimiter) RecordFailure(ctx context.Context, account, ip string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.failures[account]++; l.failures[account] < l.threshold {
+		return 0, nil
+	}
+	l.failures[account] = 0
+	l.levels[account]++
+	l.locked[account] = l.base << (l.levels[account] - 1)
+	return l.locked[account], nil
+}
+
+func (l *fakeLimiter) Reset(ctx context.Context, account string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.failures, account)
+	return nil
+}
+
+func (l *fakeLimiter) Unlock(ctx context.Context, account, ip string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.failures, account)
+	delete(l.levels, account)
+	delete(l.locked, account)
+	return nil
+}
+
+func TestUserHandler_VerifyPasswordLockout(t *testing.T) {
+	ctx := context.Background()
+	d := newFakeDB(
+		model.User{ID: 1, Email: "a@example.com", Password: "hashed:secret"},
+		// 存量的明文密码
+		model.User{ID: 2, Email: "b@example.com", Password: "legacy"},
+	)
+	l := newFakeLimiter(3)
+	u := NewUserHandler(nil, fakeRedis{}, d, nil, nil, fakeHasher{}, nil, l, nil, nil, nil, log.DefaultLogger)
+
+	unlock := func() {
+		if err := u.UnlockAccount(ctx, "a@example.com", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cases := []struct {
+		name     string
+		email    string
+		password string
+		// 期望的错误，为 nil 表示登录成功
+		expect error
+		// 期望的剩余锁定时长，只在返回 *errcode.LockedError 时检查
+		retry time.Duration
+		// 执行本步骤之前调用
+		before func()
+	}{
+		{name: "wrong 1", email: "a@example.com", password: "x", expect: errcode.PasswordIncorrect},
+		{name: "wrong 2", email: "a@example.com", password: "x", expect: errcode.PasswordIncorrect},
+		// 达到阈值的这一次直接返回锁定
+		{name: "wrong 3 locks", email: "a@example.com", password: "x", expect: errcode.AccountLocked, retry: time.Minute},
+		// 锁定期间正确的密码同样被拒绝
+		{name: "locked", email: "a@example.com", password: "secret", expect: errcode.AccountLocked, retry: time.Minute},
+		// 解除锁定后再次达到阈值，锁定时长逐级翻倍
+		{name: "wrong 4", email: "a@example.com", password: "x", expect: errcode.PasswordIncorrect, before: func() {
+			l.mu.Lock()
+			delete(l.locked, "a@example.com")
+			l.mu.Unlock()
+		}},
+		{name: "wrong 5", email: "a@example.com", password: "x", expect: errcode.PasswordIncorrect},
+		{name: "wrong 6 escalates", email: "a@example.com", password: "x", expect: errcode.AccountLocked, retry: 2 * time.Minute},
+		{name: "unlocked", email: "a@example.com", password: "secret", before: unlock},
+		// 不存在的账号同样计入失败次数
+		{name: "unknown 1", email: "c@example.com", password: "x", expect: errcode.UserNotFound},
+		{name: "unknown 2", email: "c@example.com", password: "x", expect: errcode.UserNotFound},
+		{name: "unknown 3 locks", email: "c@example.com", password: "x", expect: errcode.AccountLocked, retry: time.Minute},
+		{name: "legacy", email: "b@example.com", password: "legacy"},
+	}
+	for _, c := range cases {
+		if c.before != nil {
+			c.before()
+		}
+		user, err := u.VerifyPassword(ctx, c.email, c.password, "1.1.1.1")
+		if !errors.Is(err, c.expect) {
+			t.Fatalf("%s: expected %v, got %v", c.name, c.expect, err)
+		}
+		var locked *errcode.LockedError
+		if errors.As(err, &locked) && locked.RetryAfter != c.retry {
+			t.Errorf("%s: expected retry after %s, got %s", c.name, c.retry, locked.RetryAfter)
+		}
+		if c.expect == nil && user.Email != c.email {
+			t.Errorf("%s: expected user %s, got %+v", c.name, c.email, user)
+		}
+	}
+
+	// 存量明文密码校验通过后重新计算哈希
+	if user, _ := d.GetUserByID(ctx, 2); user.Password != "hashed:legacy" {
+		t.Errorf("expected legacy password to be rehashed, got %q", user.Password)
+	}
+}
+
+func TestUserHandler_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	d := newFakeDB(model.User{ID: 1, Email: "a@example.com", Password: "hashed:old"})
+	u := NewUserHandler(nil, fakeRedis{}, d, fakeNotifier{}, nil, fakeHasher{}, nil, newFakeLimiter(2), nil, nil, nil, log.DefaultLogger)
+
+	cases := []struct {
+		name     string
+		old, new string
+		expect   error
+	}{
+		{"wrong", "x", "new", errcode.PasswordIncorrect},
+		{"ok", "old", "new", nil},
+		// 修改之后旧密码不能再使用，这一次失败达到阈值
+		{"stale", "old", "other", errcode.AccountLocked},
+	}
+	for _, c := range cases {
+		if err := u.ChangePassword(ctx, 1, c.old, c.new, ""); !errors.Is(err, c.expect) {
+			t.Fatalf("%s: expected %v, got %v", c.name, c.expect, err)
+		}
+	}
+	if user, _ := d.GetUserByID(ctx, 1); user.Password != "hashed:new" {
+		t.Errorf("expected password to be changed, got %q", user.Password)
+	}
+}
+
+// fakeNotifier 只接收通知邮件
+type fakeNotifier struct {
+	EmailWorker
+}
+
+func (fakeNotifier) SendNotice(ctx context.Context, kind, email string, data map[string]string) error {
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -26,11 +27,21 @@ type Bootstrap struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Server   *Server       `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
-	Data     *Data         `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Email    *EmailConf    `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	Registry *RegistryConf `protobuf:"bytes,4,opt,name=registry,proto3" json:"registry,omitempty"`
-	Log      *LogConf      `protobuf:"bytes,5,opt,name=log,proto3" json:"log,omitempty"`
+	Server     *Server         `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Data       *Data           `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Email      *EmailConf      `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Registry   *RegistryConf   `protobuf:"bytes,4,opt,name=registry,proto3" json:"registry,omitempty"`
+	Log        *LogConf        `protobuf:"bytes,5,opt,name=log,proto3" json:"log,omitempty"`
+	Password   *PasswordConf   `protobuf:"bytes,6,opt,name=password,proto3" json:"password,omitempty"`
+	Token      *TokenConf      `protobuf:"bytes,7,opt,name=token,proto3" json:"token,omitempty"`
+	Lockout    *LockoutConf    `protobuf:"bytes,8,opt,name=lockout,proto3" json:"lockout,omitempty"`
+	TwoFactor  *TwoFactorConf  `protobuf:"bytes,9,opt,name=twoFactor,proto3" json:"twoFactor,omitempty"`
+	VerifyCode *VerifyCodeConf `protobuf:"bytes,10,opt,name=verifyCode,proto3" json:"verifyCode,omitempty"`
+	Outbox     *OutboxConf     `protobuf:"bytes,11,opt,name=outbox,proto3" json:"outbox,omitempty"`
+	Sms        *SmsConf        `protobuf:"bytes,12,opt,name=sms,proto3" json:"sms,omitempty"`
+	Id         *IDConf         `protobuf:"bytes,13,opt,name=id,proto3" json:"id,omitempty"`
+	PublicID   *PublicIDConf   `protobuf:"bytes,14,opt,name=publicID,proto3" json:"publicID,omitempty"`
+	Cache      *CacheConf      `protobuf:"bytes,15,opt,name=cache,proto3" json:"cache,omitempty"`
 }
 
 func (x *Bootstrap) Reset() {
@@ -98,12 +109,88 @@ func (x *Bootstrap) GetLog() *LogConf {
 	return nil
 }
 
+func (x *Bootstrap) GetPassword() *PasswordConf {
+	if x != nil {
+		return x.Password
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetToken() *TokenConf {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetLockout() *LockoutConf {
+	if x != nil {
+		return x.Lockout
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetTwoFactor() *TwoFactorConf {
+	if x != nil {
+		return x.TwoFactor
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetVerifyCode() *VerifyCodeConf {
+	if x != nil {
+		return x.VerifyCode
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetOutbox() *OutboxConf {
+	if x != nil {
+		return x.Outbox
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetSms() *SmsConf {
+	if x != nil {
+		return x.Sms
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetId() *IDConf {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetPublicID() *PublicIDConf {
+	if x != nil {
+		return x.PublicID
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetCache() *CacheConf {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
 type Server struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Grpc *Server_GRPC `protobuf:"bytes,1,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	Http *Server_HTTP `protobuf:"bytes,2,opt,name=http,proto3" json:"http,omitempty"`
+	// 受信代理（网关、负载均衡）的 IP 或 CIDR，只有直连地址属于受信代理时才读取 x-forwarded-for
+	TrustedProxies []string `protobuf:"bytes,3,rep,name=trustedProxies,proto3" json:"trustedProxies,omitempty"`
+	// 管理员接口（UnlockAccount）与调试接口要求请求头 x-admin-token 与之相同，为空时拒绝所有管理员接口调用
+	AdminToken string `protobuf:"bytes,4,opt,name=adminToken,proto3" json:"adminToken,omitempty"`
+	Env        string `protobuf:"bytes,5,opt,name=env,proto3" json:"env,omitempty"` //dev | test | prod，默认为 prod，只有 dev 环境允许开启 VerifyCodeConf.exposeCodes
 }
 
 func (x *Server) Reset() {
@@ -143,6 +230,34 @@ func (x *Server) GetGrpc() *Server_GRPC {
 	return nil
 }
 
+func (x *Server) GetHttp() *Server_HTTP {
+	if x != nil {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *Server) GetTrustedProxies() []string {
+	if x != nil {
+		return x.TrustedProxies
+	}
+	return nil
+}
+
+func (x *Server) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *Server) GetEnv() string {
+	if x != nil {
+		return x.Env
+	}
+	return ""
+}
+
 type Data struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -201,26 +316,1233 @@ type EmailConf struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sender            string `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
-	Secret            string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
-	ExpirationSeconds int64  `protobuf:"varint,3,opt,name=expirationSeconds,proto3" json:"expirationSeconds,omitempty"`
+	Sender            string          `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	Secret            string          `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	ExpirationSeconds int64           `protobuf:"varint,3,opt,name=expirationSeconds,proto3" json:"expirationSeconds,omitempty"`
+	Backend           string          `protobuf:"bytes,4,opt,name=backend,proto3" json:"backend,omitempty"` //smtp | file | stdout | memory，默认为 smtp
+	Smtp              *EmailConf_SMTP `protobuf:"bytes,5,opt,name=smtp,proto3" json:"smtp,omitempty"`
+	FilePath          string          `protobuf:"bytes,6,opt,name=filePath,proto3" json:"filePath,omitempty"`           //backend 为 file 时邮件追加写入的文件
+	TemplateDir       string          `protobuf:"bytes,7,opt,name=templateDir,proto3" json:"templateDir,omitempty"`     //邮件模板目录，其中的模板覆盖同名的内置模板
+	DefaultLocale     string          `protobuf:"bytes,8,opt,name=defaultLocale,proto3" json:"defaultLocale,omitempty"` //请求与用户都没有指定语言时使用，默认为 zh-CN
+	// 开启后按邮箱服务商规则规范化，例如 Gmail 地址忽略点号与 + 后缀。
+	// 已有用户数据后修改该项必须先执行 cmd/emailmigrate 处理冲突并改写存量邮箱，否则规范形式改变的账号只能按原始写法找到
+	ProviderRules bool `protobuf:"varint,9,opt,name=providerRules,proto3" json:"providerRules,omitempty"`
+}
+
+func (x *EmailConf) Reset() {
+	*x = EmailConf{}
+	mi := &file_conf_conf_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailConf) ProtoMessage() {}
+
+func (x *EmailConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailConf.ProtoReflect.Descriptor instead.
+func (*EmailConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EmailConf) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *EmailConf) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EmailConf) GetExpirationSeconds() int64 {
+	if x != nil {
+		return x.ExpirationSeconds
+	}
+	return 0
+}
+
+func (x *EmailConf) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *EmailConf) GetSmtp() *EmailConf_SMTP {
+	if x != nil {
+		return x.Smtp
+	}
+	return nil
+}
+
+func (x *EmailConf) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *EmailConf) GetTemplateDir() string {
+	if x != nil {
+		return x.TemplateDir
+	}
+	return ""
+}
+
+func (x *EmailConf) GetDefaultLocale() string {
+	if x != nil {
+		return x.DefaultLocale
+	}
+	return ""
+}
+
+func (x *EmailConf) GetProviderRules() bool {
+	if x != nil {
+		return x.ProviderRules
+	}
+	return false
+}
+
+type PasswordConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Algorithm  string               `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"` //新密码使用的哈希算法: bcrypt | argon2id
+	BcryptCost int64                `protobuf:"varint,2,opt,name=bcryptCost,proto3" json:"bcryptCost,omitempty"`
+	Argon2     *PasswordConf_Argon2 `protobuf:"bytes,3,opt,name=argon2,proto3" json:"argon2,omitempty"`
+	Policy     *PasswordConf_Policy `protobuf:"bytes,4,opt,name=policy,proto3" json:"policy,omitempty"`
+}
+
+func (x *PasswordConf) Reset() {
+	*x = PasswordConf{}
+	mi := &file_conf_conf_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasswordConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordConf) ProtoMessage() {}
+
+func (x *PasswordConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasswordConf.ProtoReflect.Descriptor instead.
+func (*PasswordConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PasswordConf) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *PasswordConf) GetBcryptCost() int64 {
+	if x != nil {
+		return x.BcryptCost
+	}
+	return 0
+}
+
+func (x *PasswordConf) GetArgon2() *PasswordConf_Argon2 {
+	if x != nil {
+		return x.Argon2
+	}
+	return nil
+}
+
+func (x *PasswordConf) GetPolicy() *PasswordConf_Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type TokenConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Issuer                 string               `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	Audience               string               `protobuf:"bytes,2,opt,name=audience,proto3" json:"audience,omitempty"`
+	AccessTTL              *durationpb.Duration `protobuf:"bytes,3,opt,name=accessTTL,proto3" json:"accessTTL,omitempty"`
+	RefreshTTL             *durationpb.Duration `protobuf:"bytes,4,opt,name=refreshTTL,proto3" json:"refreshTTL,omitempty"`
+	Keys                   []*TokenConf_Key     `protobuf:"bytes,5,rep,name=keys,proto3" json:"keys,omitempty"`
+	IntrospectionCacheSize int64                `protobuf:"varint,6,opt,name=introspectionCacheSize,proto3" json:"introspectionCacheSize,omitempty"`
+	IntrospectionCacheTTL  *durationpb.Duration `protobuf:"bytes,7,opt,name=introspectionCacheTTL,proto3" json:"introspectionCacheTTL,omitempty"`
+}
+
+func (x *TokenConf) Reset() {
+	*x = TokenConf{}
+	mi := &file_conf_conf_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenConf) ProtoMessage() {}
+
+func (x *TokenConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenConf.ProtoReflect.Descriptor instead.
+func (*TokenConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TokenConf) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *TokenConf) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *TokenConf) GetAccessTTL() *durationpb.Duration {
+	if x != nil {
+		return x.AccessTTL
+	}
+	return nil
+}
+
+func (x *TokenConf) GetRefreshTTL() *durationpb.Duration {
+	if x != nil {
+		return x.RefreshTTL
+	}
+	return nil
+}
+
+func (x *TokenConf) GetKeys() []*TokenConf_Key {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *TokenConf) GetIntrospectionCacheSize() int64 {
+	if x != nil {
+		return x.IntrospectionCacheSize
+	}
+	return 0
+}
+
+func (x *TokenConf) GetIntrospectionCacheTTL() *durationpb.Duration {
+	if x != nil {
+		return x.IntrospectionCacheTTL
+	}
+	return nil
+}
+
+type RegistryConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addr string `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+}
+
+func (x *RegistryConf) Reset() {
+	*x = RegistryConf{}
+	mi := &file_conf_conf_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistryConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistryConf) ProtoMessage() {}
+
+func (x *RegistryConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistryConf.ProtoReflect.Descriptor instead.
+func (*RegistryConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RegistryConf) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+type LogConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stdout      bool               `protobuf:"varint,1,opt,name=stdout,proto3" json:"stdout,omitempty"` //是否打印到控制台
+	EnableFile  bool               `protobuf:"varint,2,opt,name=enableFile,proto3" json:"enableFile,omitempty"`
+	EnableKafka bool               `protobuf:"varint,3,opt,name=enableKafka,proto3" json:"enableKafka,omitempty"`
+	File        *LogConf_FileConf  `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	Kafka       *LogConf_KafkaConf `protobuf:"bytes,5,opt,name=kafka,proto3" json:"kafka,omitempty"`
+}
+
+func (x *LogConf) Reset() {
+	*x = LogConf{}
+	mi := &file_conf_conf_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogConf) ProtoMessage() {}
+
+func (x *LogConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogConf.ProtoReflect.Descriptor instead.
+func (*LogConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LogConf) GetStdout() bool {
+	if x != nil {
+		return x.Stdout
+	}
+	return false
+}
+
+func (x *LogConf) GetEnableFile() bool {
+	if x != nil {
+		return x.EnableFile
+	}
+	return false
+}
+
+func (x *LogConf) GetEnableKafka() bool {
+	if x != nil {
+		return x.EnableKafka
+	}
+	return false
+}
+
+func (x *LogConf) GetFile() *LogConf_FileConf {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *LogConf) GetKafka() *LogConf_KafkaConf {
+	if x != nil {
+		return x.Kafka
+	}
+	return nil
+}
+
+type LockoutConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountThreshold     int64                `protobuf:"varint,1,opt,name=accountThreshold,proto3" json:"accountThreshold,omitempty"` //窗口期内同一账号允许失败的次数
+	IpThreshold          int64                `protobuf:"varint,2,opt,name=ipThreshold,proto3" json:"ipThreshold,omitempty"`           //窗口期内同一 IP 允许失败的次数
+	Window               *durationpb.Duration `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`                      //失败次数的统计窗口
+	BaseLockDuration     *durationpb.Duration `protobuf:"bytes,4,opt,name=baseLockDuration,proto3" json:"baseLockDuration,omitempty"`  //首次锁定的时长，之后每次锁定时长翻倍
+	MaxLockDuration      *durationpb.Duration `protobuf:"bytes,5,opt,name=maxLockDuration,proto3" json:"maxLockDuration,omitempty"`
+	EscalationResetAfter *durationpb.Duration `protobuf:"bytes,6,opt,name=escalationResetAfter,proto3" json:"escalationResetAfter,omitempty"` //超过该时长没有再被锁定，锁定时长重新从 baseLockDuration 开始
+}
+
+func (x *LockoutConf) Reset() {
+	*x = LockoutConf{}
+	mi := &file_conf_conf_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockoutConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockoutConf) ProtoMessage() {}
+
+func (x *LockoutConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockoutConf.ProtoReflect.Descriptor instead.
+func (*LockoutConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LockoutConf) GetAccountThreshold() int64 {
+	if x != nil {
+		return x.AccountThreshold
+	}
+	return 0
+}
+
+func (x *LockoutConf) GetIpThreshold() int64 {
+	if x != nil {
+		return x.IpThreshold
+	}
+	return 0
+}
+
+func (x *LockoutConf) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *LockoutConf) GetBaseLockDuration() *durationpb.Duration {
+	if x != nil {
+		return x.BaseLockDuration
+	}
+	return nil
+}
+
+func (x *LockoutConf) GetMaxLockDuration() *durationpb.Duration {
+	if x != nil {
+		return x.MaxLockDuration
+	}
+	return nil
+}
+
+func (x *LockoutConf) GetEscalationResetAfter() *durationpb.Duration {
+	if x != nil {
+		return x.EscalationResetAfter
+	}
+	return nil
+}
+
+type TwoFactorConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Issuer               string               `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"` //验证器应用中展示的发行方
+	Skew                 int64                `protobuf:"varint,2,opt,name=skew,proto3" json:"skew,omitempty"`    //允许前后偏移的时间片数
+	RecoveryCodeCount    int64                `protobuf:"varint,3,opt,name=recoveryCodeCount,proto3" json:"recoveryCodeCount,omitempty"`
+	ChallengeTTL         *durationpb.Duration `protobuf:"bytes,4,opt,name=challengeTTL,proto3" json:"challengeTTL,omitempty"` //密码校验通过后完成二次验证的时限
+	ChallengeMaxAttempts int64                `protobuf:"varint,5,opt,name=challengeMaxAttempts,proto3" json:"challengeMaxAttempts,omitempty"`
+}
+
+func (x *TwoFactorConf) Reset() {
+	*x = TwoFactorConf{}
+	mi := &file_conf_conf_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TwoFactorConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TwoFactorConf) ProtoMessage() {}
+
+func (x *TwoFactorConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TwoFactorConf.ProtoReflect.Descriptor instead.
+func (*TwoFactorConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TwoFactorConf) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *TwoFactorConf) GetSkew() int64 {
+	if x != nil {
+		return x.Skew
+	}
+	return 0
+}
+
+func (x *TwoFactorConf) GetRecoveryCodeCount() int64 {
+	if x != nil {
+		return x.RecoveryCodeCount
+	}
+	return 0
+}
+
+func (x *TwoFactorConf) GetChallengeTTL() *durationpb.Duration {
+	if x != nil {
+		return x.ChallengeTTL
+	}
+	return nil
+}
+
+func (x *TwoFactorConf) GetChallengeMaxAttempts() int64 {
+	if x != nil {
+		return x.ChallengeMaxAttempts
+	}
+	return 0
+}
+
+type VerifyCodeConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Length      int64                `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`           //验证码位数
+	Ttl         *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`                  //为空时使用 EmailConf.expirationSeconds
+	MaxAttempts int64                `protobuf:"varint,3,opt,name=maxAttempts,proto3" json:"maxAttempts,omitempty"` //单个验证码允许校验的次数
+	Cooldown    *durationpb.Duration `protobuf:"bytes,4,opt,name=cooldown,proto3" json:"cooldown,omitempty"`        //同一用途、同一地址两次发送的最小间隔
+	DailyQuota  int64                `protobuf:"varint,5,opt,name=dailyQuota,proto3" json:"dailyQuota,omitempty"`   //同一地址每天最多发送的验证码数量
+	Secret      string               `protobuf:"bytes,6,opt,name=secret,proto3" json:"secret,omitempty"`            //计算验证码摘要使用的密钥
+	ExposeCodes bool                 `protobuf:"varint,7,opt,name=exposeCodes,proto3" json:"exposeCodes,omitempty"` //开启后可通过 HTTP 调试接口读取验证码，需要管理员凭证，Server.env 不是 dev 时拒绝启动
+}
+
+func (x *VerifyCodeConf) Reset() {
+	*x = VerifyCodeConf{}
+	mi := &file_conf_conf_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyCodeConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCodeConf) ProtoMessage() {}
+
+func (x *VerifyCodeConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyCodeConf.ProtoReflect.Descriptor instead.
+func (*VerifyCodeConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VerifyCodeConf) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *VerifyCodeConf) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *VerifyCodeConf) GetMaxAttempts() int64 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *VerifyCodeConf) GetCooldown() *durationpb.Duration {
+	if x != nil {
+		return x.Cooldown
+	}
+	return nil
+}
+
+func (x *VerifyCodeConf) GetDailyQuota() int64 {
+	if x != nil {
+		return x.DailyQuota
+	}
+	return 0
+}
+
+func (x *VerifyCodeConf) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *VerifyCodeConf) GetExposeCodes() bool {
+	if x != nil {
+		return x.ExposeCodes
+	}
+	return false
+}
+
+type OutboxConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PollInterval *durationpb.Duration `protobuf:"bytes,1,opt,name=pollInterval,proto3" json:"pollInterval,omitempty"` //没有待发送邮件时的轮询间隔
+	BatchSize    int64                `protobuf:"varint,2,opt,name=batchSize,proto3" json:"batchSize,omitempty"`      //每次领取的邮件数量
+	MaxAttempts  int64                `protobuf:"varint,3,opt,name=maxAttempts,proto3" json:"maxAttempts,omitempty"`  //超过该次数仍发送失败的邮件转为 dead
+	BaseBackoff  *durationpb.Duration `protobuf:"bytes,4,opt,name=baseBackoff,proto3" json:"baseBackoff,omitempty"`   //第 n 次失败后等待 baseBackoff * 2^(n-1) 再重试
+	MaxBackoff   *durationpb.Duration `protobuf:"bytes,5,opt,name=maxBackoff,proto3" json:"maxBackoff,omitempty"`
+	Lease        *durationpb.Duration `protobuf:"bytes,6,opt,name=lease,proto3" json:"lease,omitempty"`         //领取邮件后的租约，实例崩溃时租约到期后由其他实例重新发送
+	Retention    *durationpb.Duration `protobuf:"bytes,7,opt,name=retention,proto3" json:"retention,omitempty"` //已发送的邮件保留的时长，默认为 7 天，之后被删除
+}
+
+func (x *OutboxConf) Reset() {
+	*x = OutboxConf{}
+	mi := &file_conf_conf_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutboxConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutboxConf) ProtoMessage() {}
+
+func (x *OutboxConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutboxConf.ProtoReflect.Descriptor instead.
+func (*OutboxConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OutboxConf) GetPollInterval() *durationpb.Duration {
+	if x != nil {
+		return x.PollInterval
+	}
+	return nil
+}
+
+func (x *OutboxConf) GetBatchSize() int64 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *OutboxConf) GetMaxAttempts() int64 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *OutboxConf) GetBaseBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.BaseBackoff
+	}
+	return nil
+}
+
+func (x *OutboxConf) GetMaxBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.MaxBackoff
+	}
+	return nil
+}
+
+func (x *OutboxConf) GetLease() *durationpb.Duration {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
+func (x *OutboxConf) GetRetention() *durationpb.Duration {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+type SmsConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Backend            string `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`                       //log | memory，默认为 log，只把短信写入日志
+	DefaultCountryCode string `protobuf:"bytes,2,opt,name=defaultCountryCode,proto3" json:"defaultCountryCode,omitempty"` //号码没有国家码时使用，例如 86
+}
+
+func (x *SmsConf) Reset() {
+	*x = SmsConf{}
+	mi := &file_conf_conf_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SmsConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SmsConf) ProtoMessage() {}
+
+func (x *SmsConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SmsConf.ProtoReflect.Descriptor instead.
+func (*SmsConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SmsConf) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *SmsConf) GetDefaultCountryCode() string {
+	if x != nil {
+		return x.DefaultCountryCode
+	}
+	return ""
+}
+
+type IDConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Generator        string                 `protobuf:"bytes,1,opt,name=generator,proto3" json:"generator,omitempty"`               //counter | snowflake | segment，默认为 counter，使用 redis 计数器生成自增 ID
+	Epoch            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=epoch,proto3" json:"epoch,omitempty"`                       //snowflake 时间戳的起点，默认为 2024-01-01，上线后不能修改
+	LeaseTTL         *durationpb.Duration   `protobuf:"bytes,3,opt,name=leaseTTL,proto3" json:"leaseTTL,omitempty"`                 //snowflake worker ID 租约时长，默认为 30s
+	MaxClockBackward *durationpb.Duration   `protobuf:"bytes,4,opt,name=maxClockBackward,proto3" json:"maxClockBackward,omitempty"` //允许等待的时钟回拨，默认为 10ms
+	SegmentStep      uint64                 `protobuf:"varint,5,opt,name=segmentStep,proto3" json:"segmentStep,omitempty"`          //segment 每次从数据库预留的 ID 数量，默认为 1000
+	SegmentBizTag    string                 `protobuf:"bytes,6,opt,name=segmentBizTag,proto3" json:"segmentBizTag,omitempty"`       //segment 在 id_segments 表中的记录，默认为 user
+	SegmentStart     uint64                 `protobuf:"varint,7,opt,name=segmentStart,proto3" json:"segmentStart,omitempty"`        //segment 首次创建记录时的 max_id，从 counter 切换时需要不小于现有的最大用户 ID
+}
+
+func (x *IDConf) Reset() {
+	*x = IDConf{}
+	mi := &file_conf_conf_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IDConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IDConf) ProtoMessage() {}
+
+func (x *IDConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IDConf.ProtoReflect.Descriptor instead.
+func (*IDConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *IDConf) GetGenerator() string {
+	if x != nil {
+		return x.Generator
+	}
+	return ""
+}
+
+func (x *IDConf) GetEpoch() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Epoch
+	}
+	return nil
+}
+
+func (x *IDConf) GetLeaseTTL() *durationpb.Duration {
+	if x != nil {
+		return x.LeaseTTL
+	}
+	return nil
+}
+
+func (x *IDConf) GetMaxClockBackward() *durationpb.Duration {
+	if x != nil {
+		return x.MaxClockBackward
+	}
+	return nil
+}
+
+func (x *IDConf) GetSegmentStep() uint64 {
+	if x != nil {
+		return x.SegmentStep
+	}
+	return 0
+}
+
+func (x *IDConf) GetSegmentBizTag() string {
+	if x != nil {
+		return x.SegmentBizTag
+	}
+	return ""
+}
+
+func (x *IDConf) GetSegmentStart() uint64 {
+	if x != nil {
+		return x.SegmentStart
+	}
+	return 0
+}
+
+type PublicIDConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Secret string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"` //对外用户 ID 的编码密钥，至少 16 字节，修改后之前返回的用户 ID 全部失效
+}
+
+func (x *PublicIDConf) Reset() {
+	*x = PublicIDConf{}
+	mi := &file_conf_conf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicIDConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicIDConf) ProtoMessage() {}
+
+func (x *PublicIDConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicIDConf.ProtoReflect.Descriptor instead.
+func (*PublicIDConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PublicIDConf) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type CacheConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LoadLock    bool                 `protobuf:"varint,1,opt,name=loadLock,proto3" json:"loadLock,omitempty"`       //缓存未命中时是否使用 redis 锁保证多个实例中只有一个查询数据库，单个实例内始终合并相同用户的查询
+	LoadLockTTL *durationpb.Duration `protobuf:"bytes,2,opt,name=loadLockTTL,proto3" json:"loadLockTTL,omitempty"`  //回源锁的过期时间，默认为 3s
+	LoadWait    *durationpb.Duration `protobuf:"bytes,3,opt,name=loadWait,proto3" json:"loadWait,omitempty"`        //没有抢到回源锁时等待其他实例写入缓存的最长时间，默认为 500ms，超时后自己查询数据库
+	Local       bool                 `protobuf:"varint,4,opt,name=local,proto3" json:"local,omitempty"`             //是否在 redis 之前使用进程内缓存，用户信息修改后通过 redis 发布订阅通知所有实例删除
+	LocalSize   int64                `protobuf:"varint,5,opt,name=localSize,proto3" json:"localSize,omitempty"`     //进程内缓存的用户数量上限，默认为 10000
+	LocalTTL    *durationpb.Duration `protobuf:"bytes,6,opt,name=localTTL,proto3" json:"localTTL,omitempty"`        //进程内缓存的过期时间，默认为 5s，订阅断开期间最多读到这么久之前的数据
+	ExposeStats bool                 `protobuf:"varint,7,opt,name=exposeStats,proto3" json:"exposeStats,omitempty"` //开启后可通过 HTTP 调试接口读取进程内缓存的命中统计，HTTP 端口对外开放时不要开启
+}
+
+func (x *CacheConf) Reset() {
+	*x = CacheConf{}
+	mi := &file_conf_conf_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheConf) ProtoMessage() {}
+
+func (x *CacheConf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheConf.ProtoReflect.Descriptor instead.
+func (*CacheConf) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CacheConf) GetLoadLock() bool {
+	if x != nil {
+		return x.LoadLock
+	}
+	return false
+}
+
+func (x *CacheConf) GetLoadLockTTL() *durationpb.Duration {
+	if x != nil {
+		return x.LoadLockTTL
+	}
+	return nil
+}
+
+func (x *CacheConf) GetLoadWait() *durationpb.Duration {
+	if x != nil {
+		return x.LoadWait
+	}
+	return nil
+}
+
+func (x *CacheConf) GetLocal() bool {
+	if x != nil {
+		return x.Local
+	}
+	return false
+}
+
+func (x *CacheConf) GetLocalSize() int64 {
+	if x != nil {
+		return x.LocalSize
+	}
+	return 0
+}
+
+func (x *CacheConf) GetLocalTTL() *durationpb.Duration {
+	if x != nil {
+		return x.LocalTTL
+	}
+	return nil
+}
+
+func (x *CacheConf) GetExposeStats() bool {
+	if x != nil {
+		return x.ExposeStats
+	}
+	return false
+}
+
+type Server_HTTP struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Network string               `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr    string               `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *Server_HTTP) Reset() {
+	*x = Server_HTTP{}
+	mi := &file_conf_conf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_HTTP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_HTTP) ProtoMessage() {}
+
+func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_HTTP.ProtoReflect.Descriptor instead.
+func (*Server_HTTP) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *Server_HTTP) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Server_HTTP) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Server_HTTP) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Server_GRPC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Network string               `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr    string               `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *Server_GRPC) Reset() {
+	*x = Server_GRPC{}
+	mi := &file_conf_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_GRPC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_GRPC) ProtoMessage() {}
+
+func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_GRPC.ProtoReflect.Descriptor instead.
+func (*Server_GRPC) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *Server_GRPC) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Server_GRPC) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Data_Database struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Driver string `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *Data_Database) Reset() {
+	*x = Data_Database{}
+	mi := &file_conf_conf_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Database) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Database) ProtoMessage() {}
+
+func (x *Data_Database) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
+func (*Data_Database) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *Data_Database) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *Data_Database) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type Data_Redis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addr        string `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Password    string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	MaxIdle     int64  `protobuf:"varint,3,opt,name=maxIdle,proto3" json:"maxIdle,omitempty"`
+	IdleTimeout int64  `protobuf:"varint,4,opt,name=idleTimeout,proto3" json:"idleTimeout,omitempty"` //单位s
+	MaxActive   int64  `protobuf:"varint,5,opt,name=maxActive,proto3" json:"maxActive,omitempty"`
+	// 当连接数达到上限时，新的请求是等待还是立即报错.
+	Wait              bool  `protobuf:"varint,6,opt,name=wait,proto3" json:"wait,omitempty"`
+	ExpirationSeconds int64 `protobuf:"varint,7,opt,name=expirationSeconds,proto3" json:"expirationSeconds,omitempty"`
 }
 
-func (x *EmailConf) Reset() {
-	*x = EmailConf{}
-	mi := &file_conf_conf_proto_msgTypes[3]
+func (x *Data_Redis) Reset() {
+	*x = Data_Redis{}
+	mi := &file_conf_conf_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EmailConf) String() string {
+func (x *Data_Redis) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmailConf) ProtoMessage() {}
+func (*Data_Redis) ProtoMessage() {}
 
-func (x *EmailConf) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[3]
+func (x *Data_Redis) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -231,55 +1553,87 @@ func (x *EmailConf) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmailConf.ProtoReflect.Descriptor instead.
-func (*EmailConf) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Data_Redis.ProtoReflect.Descriptor instead.
+func (*Data_Redis) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 1}
 }
 
-func (x *EmailConf) GetSender() string {
+func (x *Data_Redis) GetAddr() string {
 	if x != nil {
-		return x.Sender
+		return x.Addr
 	}
 	return ""
 }
 
-func (x *EmailConf) GetSecret() string {
+func (x *Data_Redis) GetPassword() string {
 	if x != nil {
-		return x.Secret
+		return x.Password
 	}
 	return ""
 }
 
-func (x *EmailConf) GetExpirationSeconds() int64 {
+func (x *Data_Redis) GetMaxIdle() int64 {
+	if x != nil {
+		return x.MaxIdle
+	}
+	return 0
+}
+
+func (x *Data_Redis) GetIdleTimeout() int64 {
+	if x != nil {
+		return x.IdleTimeout
+	}
+	return 0
+}
+
+func (x *Data_Redis) GetMaxActive() int64 {
+	if x != nil {
+		return x.MaxActive
+	}
+	return 0
+}
+
+func (x *Data_Redis) GetWait() bool {
+	if x != nil {
+		return x.Wait
+	}
+	return false
+}
+
+func (x *Data_Redis) GetExpirationSeconds() int64 {
 	if x != nil {
 		return x.ExpirationSeconds
 	}
 	return 0
 }
 
-type RegistryConf struct {
+type EmailConf_SMTP struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Addr string `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Host     string               `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`         //默认为 smtp.qq.com
+	Port     int64                `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`        //默认为 587
+	Username string               `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"` //为空时使用 sender
+	Security string               `protobuf:"bytes,4,opt,name=security,proto3" json:"security,omitempty"` //starttls | tls | none，默认为 starttls
+	Timeout  *durationpb.Duration `protobuf:"bytes,5,opt,name=timeout,proto3" json:"timeout,omitempty"`   //一次发送从建立连接到结束的超时时间，默认为 30s
 }
 
-func (x *RegistryConf) Reset() {
-	*x = RegistryConf{}
-	mi := &file_conf_conf_proto_msgTypes[4]
+func (x *EmailConf_SMTP) Reset() {
+	*x = EmailConf_SMTP{}
+	mi := &file_conf_conf_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegistryConf) String() string {
+func (x *EmailConf_SMTP) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegistryConf) ProtoMessage() {}
+func (*EmailConf_SMTP) ProtoMessage() {}
 
-func (x *RegistryConf) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[4]
+func (x *EmailConf_SMTP) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -290,45 +1644,73 @@ func (x *RegistryConf) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegistryConf.ProtoReflect.Descriptor instead.
-func (*RegistryConf) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use EmailConf_SMTP.ProtoReflect.Descriptor instead.
+func (*EmailConf_SMTP) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 0}
 }
 
-func (x *RegistryConf) GetAddr() string {
+func (x *EmailConf_SMTP) GetHost() string {
 	if x != nil {
-		return x.Addr
+		return x.Host
 	}
 	return ""
 }
 
-type LogConf struct {
+func (x *EmailConf_SMTP) GetPort() int64 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *EmailConf_SMTP) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *EmailConf_SMTP) GetSecurity() string {
+	if x != nil {
+		return x.Security
+	}
+	return ""
+}
+
+func (x *EmailConf_SMTP) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type PasswordConf_Argon2 struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Stdout      bool               `protobuf:"varint,1,opt,name=stdout,proto3" json:"stdout,omitempty"` //是否打印到控制台
-	EnableFile  bool               `protobuf:"varint,2,opt,name=enableFile,proto3" json:"enableFile,omitempty"`
-	EnableKafka bool               `protobuf:"varint,3,opt,name=enableKafka,proto3" json:"enableKafka,omitempty"`
-	File        *LogConf_FileConf  `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
-	Kafka       *LogConf_KafkaConf `protobuf:"bytes,5,opt,name=kafka,proto3" json:"kafka,omitempty"`
+	Memory      uint32 `protobuf:"varint,1,opt,name=memory,proto3" json:"memory,omitempty"` //单位KiB
+	Iterations  uint32 `protobuf:"varint,2,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	Parallelism uint32 `protobuf:"varint,3,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	SaltLength  uint32 `protobuf:"varint,4,opt,name=saltLength,proto3" json:"saltLength,omitempty"`
+	KeyLength   uint32 `protobuf:"varint,5,opt,name=keyLength,proto3" json:"keyLength,omitempty"`
 }
 
-func (x *LogConf) Reset() {
-	*x = LogConf{}
-	mi := &file_conf_conf_proto_msgTypes[5]
+func (x *PasswordConf_Argon2) Reset() {
+	*x = PasswordConf_Argon2{}
+	mi := &file_conf_conf_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogConf) String() string {
+func (x *PasswordConf_Argon2) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogConf) ProtoMessage() {}
+func (*PasswordConf_Argon2) ProtoMessage() {}
 
-func (x *LogConf) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[5]
+func (x *PasswordConf_Argon2) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -339,71 +1721,79 @@ func (x *LogConf) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogConf.ProtoReflect.Descriptor instead.
-func (*LogConf) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use PasswordConf_Argon2.ProtoReflect.Descriptor instead.
+func (*PasswordConf_Argon2) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 0}
 }
 
-func (x *LogConf) GetStdout() bool {
+func (x *PasswordConf_Argon2) GetMemory() uint32 {
 	if x != nil {
-		return x.Stdout
+		return x.Memory
 	}
-	return false
+	return 0
 }
 
-func (x *LogConf) GetEnableFile() bool {
+func (x *PasswordConf_Argon2) GetIterations() uint32 {
 	if x != nil {
-		return x.EnableFile
+		return x.Iterations
 	}
-	return false
+	return 0
 }
 
-func (x *LogConf) GetEnableKafka() bool {
+func (x *PasswordConf_Argon2) GetParallelism() uint32 {
 	if x != nil {
-		return x.EnableKafka
+		return x.Parallelism
 	}
-	return false
+	return 0
 }
 
-func (x *LogConf) GetFile() *LogConf_FileConf {
+func (x *PasswordConf_Argon2) GetSaltLength() uint32 {
 	if x != nil {
-		return x.File
+		return x.SaltLength
 	}
-	return nil
+	return 0
 }
 
-func (x *LogConf) GetKafka() *LogConf_KafkaConf {
+func (x *PasswordConf_Argon2) GetKeyLength() uint32 {
 	if x != nil {
-		return x.Kafka
+		return x.KeyLength
 	}
-	return nil
+	return 0
 }
 
-type Server_GRPC struct {
+type PasswordConf_Policy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Network string               `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr    string               `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Timeout *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
-}
-
-func (x *Server_GRPC) Reset() {
-	*x = Server_GRPC{}
-	mi := &file_conf_conf_proto_msgTypes[6]
+	MinLength           int64    `protobuf:"varint,1,opt,name=minLength,proto3" json:"minLength,omitempty"`
+	MaxLength           int64    `protobuf:"varint,2,opt,name=maxLength,proto3" json:"maxLength,omitempty"`
+	RequireUpper        bool     `protobuf:"varint,3,opt,name=requireUpper,proto3" json:"requireUpper,omitempty"`
+	RequireLower        bool     `protobuf:"varint,4,opt,name=requireLower,proto3" json:"requireLower,omitempty"`
+	RequireDigit        bool     `protobuf:"varint,5,opt,name=requireDigit,proto3" json:"requireDigit,omitempty"`
+	RequireSymbol       bool     `protobuf:"varint,6,opt,name=requireSymbol,proto3" json:"requireSymbol,omitempty"`
+	ForbiddenSequences  []string `protobuf:"bytes,7,rep,name=forbiddenSequences,proto3" json:"forbiddenSequences,omitempty"`    //禁止出现的子串，不区分大小写
+	MaxRepeat           int64    `protobuf:"varint,8,opt,name=maxRepeat,proto3" json:"maxRepeat,omitempty"`                     //同一字符最多连续出现的次数
+	MaxSequence         int64    `protobuf:"varint,9,opt,name=maxSequence,proto3" json:"maxSequence,omitempty"`                 //连续递增或递减字符的最大长度
+	CommonPasswordsFile string   `protobuf:"bytes,10,opt,name=commonPasswordsFile,proto3" json:"commonPasswordsFile,omitempty"` //额外的弱密码列表文件，每行一个
+	AllowEmailSimilar   bool     `protobuf:"varint,11,opt,name=allowEmailSimilar,proto3" json:"allowEmailSimilar,omitempty"`
+}
+
+func (x *PasswordConf_Policy) Reset() {
+	*x = PasswordConf_Policy{}
+	mi := &file_conf_conf_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Server_GRPC) String() string {
+func (x *PasswordConf_Policy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Server_GRPC) ProtoMessage() {}
+func (*PasswordConf_Policy) ProtoMessage() {}
 
-func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[6]
+func (x *PasswordConf_Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -414,115 +1804,117 @@ func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Server_GRPC.ProtoReflect.Descriptor instead.
-func (*Server_GRPC) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use PasswordConf_Policy.ProtoReflect.Descriptor instead.
+func (*PasswordConf_Policy) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 1}
 }
 
-func (x *Server_GRPC) GetNetwork() string {
+func (x *PasswordConf_Policy) GetMinLength() int64 {
 	if x != nil {
-		return x.Network
+		return x.MinLength
 	}
-	return ""
+	return 0
 }
 
-func (x *Server_GRPC) GetAddr() string {
+func (x *PasswordConf_Policy) GetMaxLength() int64 {
 	if x != nil {
-		return x.Addr
+		return x.MaxLength
 	}
-	return ""
+	return 0
 }
 
-func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
+func (x *PasswordConf_Policy) GetRequireUpper() bool {
 	if x != nil {
-		return x.Timeout
+		return x.RequireUpper
 	}
-	return nil
+	return false
 }
 
-type Data_Database struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Driver string `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
-	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+func (x *PasswordConf_Policy) GetRequireLower() bool {
+	if x != nil {
+		return x.RequireLower
+	}
+	return false
 }
 
-func (x *Data_Database) Reset() {
-	*x = Data_Database{}
-	mi := &file_conf_conf_proto_msgTypes[7]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *PasswordConf_Policy) GetRequireDigit() bool {
+	if x != nil {
+		return x.RequireDigit
+	}
+	return false
 }
 
-func (x *Data_Database) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *PasswordConf_Policy) GetRequireSymbol() bool {
+	if x != nil {
+		return x.RequireSymbol
+	}
+	return false
 }
 
-func (*Data_Database) ProtoMessage() {}
+func (x *PasswordConf_Policy) GetForbiddenSequences() []string {
+	if x != nil {
+		return x.ForbiddenSequences
+	}
+	return nil
+}
 
-func (x *Data_Database) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[7]
+func (x *PasswordConf_Policy) GetMaxRepeat() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.MaxRepeat
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
-func (*Data_Database) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{2, 0}
+func (x *PasswordConf_Policy) GetMaxSequence() int64 {
+	if x != nil {
+		return x.MaxSequence
+	}
+	return 0
 }
 
-func (x *Data_Database) GetDriver() string {
+func (x *PasswordConf_Policy) GetCommonPasswordsFile() string {
 	if x != nil {
-		return x.Driver
+		return x.CommonPasswordsFile
 	}
 	return ""
 }
 
-func (x *Data_Database) GetSource() string {
+func (x *PasswordConf_Policy) GetAllowEmailSimilar() bool {
 	if x != nil {
-		return x.Source
+		return x.AllowEmailSimilar
 	}
-	return ""
+	return false
 }
 
-type Data_Redis struct {
+type TokenConf_Key struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Addr        string `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
-	Password    string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	MaxIdle     int64  `protobuf:"varint,3,opt,name=maxIdle,proto3" json:"maxIdle,omitempty"`
-	IdleTimeout int64  `protobuf:"varint,4,opt,name=idleTimeout,proto3" json:"idleTimeout,omitempty"` //单位s
-	MaxActive   int64  `protobuf:"varint,5,opt,name=maxActive,proto3" json:"maxActive,omitempty"`
-	// 当连接数达到上限时，新的请求是等待还是立即报错.
-	Wait              bool  `protobuf:"varint,6,opt,name=wait,proto3" json:"wait,omitempty"`
-	ExpirationSeconds int64 `protobuf:"varint,7,opt,name=expirationSeconds,proto3" json:"expirationSeconds,omitempty"`
+	Algorithm      string                 `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`   //HS256 | EdDSA | RS256
+	Secret         string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`         //HS256 使用的密钥
+	PrivateKey     string                 `protobuf:"bytes,3,opt,name=privateKey,proto3" json:"privateKey,omitempty"` //EdDSA/RS256 使用的 PEM 格式私钥
+	PrivateKeyFile string                 `protobuf:"bytes,4,opt,name=privateKeyFile,proto3" json:"privateKeyFile,omitempty"`
+	Kid            string                 `protobuf:"bytes,5,opt,name=kid,proto3" json:"kid,omitempty"`             //为空时使用公钥指纹
+	NotBefore      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=notBefore,proto3" json:"notBefore,omitempty"` //生效时间，生效时间最晚的密钥用于签名
+	NotAfter       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=notAfter,proto3" json:"notAfter,omitempty"`   //失效时间，之后不再接受该密钥签发的令牌
 }
 
-func (x *Data_Redis) Reset() {
-	*x = Data_Redis{}
-	mi := &file_conf_conf_proto_msgTypes[8]
+func (x *TokenConf_Key) Reset() {
+	*x = TokenConf_Key{}
+	mi := &file_conf_conf_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Redis) String() string {
+func (x *TokenConf_Key) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Redis) ProtoMessage() {}
+func (*TokenConf_Key) ProtoMessage() {}
 
-func (x *Data_Redis) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[8]
+func (x *TokenConf_Key) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,58 +1925,58 @@ func (x *Data_Redis) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_Redis.ProtoReflect.Descriptor instead.
-func (*Data_Redis) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{2, 1}
+// Deprecated: Use TokenConf_Key.ProtoReflect.Descriptor instead.
+func (*TokenConf_Key) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{5, 0}
 }
 
-func (x *Data_Redis) GetAddr() string {
+func (x *TokenConf_Key) GetAlgorithm() string {
 	if x != nil {
-		return x.Addr
+		return x.Algorithm
 	}
 	return ""
 }
 
-func (x *Data_Redis) GetPassword() string {
+func (x *TokenConf_Key) GetSecret() string {
 	if x != nil {
-		return x.Password
+		return x.Secret
 	}
 	return ""
 }
 
-func (x *Data_Redis) GetMaxIdle() int64 {
+func (x *TokenConf_Key) GetPrivateKey() string {
 	if x != nil {
-		return x.MaxIdle
+		return x.PrivateKey
 	}
-	return 0
+	return ""
 }
 
-func (x *Data_Redis) GetIdleTimeout() int64 {
+func (x *TokenConf_Key) GetPrivateKeyFile() string {
 	if x != nil {
-		return x.IdleTimeout
+		return x.PrivateKeyFile
 	}
-	return 0
+	return ""
 }
 
-func (x *Data_Redis) GetMaxActive() int64 {
+func (x *TokenConf_Key) GetKid() string {
 	if x != nil {
-		return x.MaxActive
+		return x.Kid
 	}
-	return 0
+	return ""
 }
 
-func (x *Data_Redis) GetWait() bool {
+func (x *TokenConf_Key) GetNotBefore() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Wait
+		return x.NotBefore
 	}
-	return false
+	return nil
 }
 
-func (x *Data_Redis) GetExpirationSeconds() int64 {
+func (x *TokenConf_Key) GetNotAfter() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ExpirationSeconds
+		return x.NotAfter
 	}
-	return 0
+	return nil
 }
 
 type LogConf_FileConf struct {
@@ -602,7 +1994,7 @@ type LogConf_FileConf struct {
 
 func (x *LogConf_FileConf) Reset() {
 	*x = LogConf_FileConf{}
-	mi := &file_conf_conf_proto_msgTypes[9]
+	mi := &file_conf_conf_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -614,7 +2006,7 @@ func (x *LogConf_FileConf) String() string {
 func (*LogConf_FileConf) ProtoMessage() {}
 
 func (x *LogConf_FileConf) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[9]
+	mi := &file_conf_conf_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -627,7 +2019,7 @@ func (x *LogConf_FileConf) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogConf_FileConf.ProtoReflect.Descriptor instead.
 func (*LogConf_FileConf) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{5, 0}
+	return file_conf_conf_proto_rawDescGZIP(), []int{7, 0}
 }
 
 func (x *LogConf_FileConf) GetPath() string {
@@ -683,7 +2075,7 @@ type LogConf_KafkaConf struct {
 
 func (x *LogConf_KafkaConf) Reset() {
 	*x = LogConf_KafkaConf{}
-	mi := &file_conf_conf_proto_msgTypes[10]
+	mi := &file_conf_conf_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -695,7 +2087,7 @@ func (x *LogConf_KafkaConf) String() string {
 func (*LogConf_KafkaConf) ProtoMessage() {}
 
 func (x *LogConf_KafkaConf) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[10]
+	mi := &file_conf_conf_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -708,7 +2100,7 @@ func (x *LogConf_KafkaConf) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogConf_KafkaConf.ProtoReflect.Descriptor instead.
 func (*LogConf_KafkaConf) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{5, 1}
+	return file_conf_conf_proto_rawDescGZIP(), []int{7, 1}
 }
 
 func (x *LogConf_KafkaConf) GetAddr() []string {
@@ -731,94 +2123,376 @@ var file_conf_conf_proto_rawDesc = []byte{
 	0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x0a, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x1a, 0x1e, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe7, 0x01,
-	0x0a, 0x09, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x12, 0x2a, 0x0a, 0x06, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b, 0x72,
-	0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52,
-	0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2b, 0x0a,
-	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b,
-	0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x43,
-	0x6f, 0x6e, 0x66, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x34, 0x0a, 0x08, 0x72, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b,
-	0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79,
-	0x12, 0x25, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x43, 0x6f,
-	0x6e, 0x66, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x22, 0xa0, 0x01, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x12, 0x2b, 0x0a, 0x04, 0x67, 0x72, 0x70, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x52, 0x50, 0x43, 0x52, 0x04, 0x67, 0x72, 0x70, 0x63, 0x1a,
-	0x69, 0x0a, 0x04, 0x47, 0x52, 0x50, 0x43, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd0,
+	0x05, 0x0a, 0x09, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x12, 0x2a, 0x0a, 0x06,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b,
+	0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2b,
+	0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c,
+	0x43, 0x6f, 0x6e, 0x66, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x34, 0x0a, 0x08, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x79, 0x12, 0x25, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x43,
+	0x6f, 0x6e, 0x66, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x34, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x72, 0x61,
+	0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x43, 0x6f, 0x6e, 0x66, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x2b,
+	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x43, 0x6f, 0x6e, 0x66, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x31, 0x0a, 0x07, 0x6c,
+	0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6b,
+	0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x6f, 0x75,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x07, 0x6c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x12, 0x37,
+	0x0a, 0x09, 0x74, 0x77, 0x6f, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x54,
+	0x77, 0x6f, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x09, 0x74, 0x77,
+	0x6f, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x3a, 0x0a, 0x0a, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6b, 0x72,
+	0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43,
+	0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x0a, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43,
+	0x6f, 0x64, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x62, 0x6f, 0x78, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4f, 0x75, 0x74, 0x62, 0x6f, 0x78, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x06, 0x6f, 0x75, 0x74,
+	0x62, 0x6f, 0x78, 0x12, 0x25, 0x0a, 0x03, 0x73, 0x6d, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x6d,
+	0x73, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x03, 0x73, 0x6d, 0x73, 0x12, 0x22, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x49, 0x44, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x02, 0x69, 0x64, 0x12, 0x34,
+	0x0a, 0x08, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49, 0x44, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x49, 0x44, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x08, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x49, 0x44, 0x12, 0x2b, 0x0a, 0x05, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x05, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x22, 0x92, 0x03, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x04,
+	0x67, 0x72, 0x70, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6b, 0x72, 0x61,
+	0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47,
+	0x52, 0x50, 0x43, 0x52, 0x04, 0x67, 0x72, 0x70, 0x63, 0x12, 0x2b, 0x0a, 0x04, 0x68, 0x74, 0x74,
+	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x48, 0x54, 0x54, 0x50,
+	0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65,
+	0x64, 0x50, 0x72, 0x6f, 0x78, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e,
+	0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x78, 0x69, 0x65, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x10,
+	0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x76,
+	0x1a, 0x69, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x1a, 0x69, 0x0a, 0x04, 0x47,
+	0x52, 0x50, 0x43, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x12, 0x0a,
+	0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64,
+	0x72, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0xfd, 0x02, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x35, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x08, 0x64, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x72, 0x65, 0x64, 0x69, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x73, 0x52, 0x05, 0x72,
+	0x65, 0x64, 0x69, 0x73, 0x1a, 0x3a, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x1a, 0xd3, 0x01, 0x0a, 0x05, 0x52, 0x65, 0x64, 0x69, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64,
+	0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61,
+	0x78, 0x49, 0x64, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78,
+	0x49, 0x64, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x69, 0x64, 0x6c, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x41, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x11, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0xdb, 0x03, 0x0a, 0x09, 0x45, 0x6d, 0x61, 0x69, 0x6c,
+	0x43, 0x6f, 0x6e, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x12, 0x2c, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x11, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x2e, 0x0a, 0x04,
+	0x73, 0x6d, 0x74, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6b, 0x72, 0x61,
+	0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x43, 0x6f, 0x6e,
+	0x66, 0x2e, 0x53, 0x4d, 0x54, 0x50, 0x52, 0x04, 0x73, 0x6d, 0x74, 0x70, 0x12, 0x1a, 0x0a, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x44, 0x69, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74,
+	0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x44, 0x69, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x65,
+	0x12, 0x24, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x1a, 0x9b, 0x01, 0x0a, 0x04, 0x53, 0x4d, 0x54, 0x50, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68,
+	0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x22, 0x8a, 0x06, 0x0a, 0x0c, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69,
+	0x74, 0x68, 0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x63, 0x72, 0x79, 0x70, 0x74, 0x43, 0x6f, 0x73,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x62, 0x63, 0x72, 0x79, 0x70, 0x74, 0x43,
+	0x6f, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x61, 0x72, 0x67, 0x6f, 0x6e, 0x32, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x41, 0x72,
+	0x67, 0x6f, 0x6e, 0x32, 0x52, 0x06, 0x61, 0x72, 0x67, 0x6f, 0x6e, 0x32, 0x12, 0x37, 0x0a, 0x06,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6b,
+	0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x1a, 0xa0, 0x01, 0x0a, 0x06, 0x41, 0x72, 0x67, 0x6f, 0x6e, 0x32,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x74, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x69, 0x74,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x61,
+	0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70,
+	0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x61,
+	0x6c, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x73, 0x61, 0x6c, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x6b, 0x65,
+	0x79, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6b,
+	0x65, 0x79, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x1a, 0xa6, 0x03, 0x0a, 0x06, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12,
+	0x22, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x55, 0x70, 0x70, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x55, 0x70,
+	0x70, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x4c, 0x6f,
+	0x77, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x4c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x44, 0x69, 0x67, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x44, 0x69, 0x67, 0x69, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x53, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x12, 0x2e, 0x0a, 0x12, 0x66, 0x6f, 0x72, 0x62, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x66,
+	0x6f, 0x72, 0x62, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x12,
+	0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x30, 0x0a, 0x13, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x50, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x45, 0x6d, 0x61, 0x69,
+	0x6c, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61,
+	0x72, 0x22, 0xf5, 0x04, 0x0a, 0x09, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x12,
+	0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x75, 0x64, 0x69, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x64, 0x69, 0x65,
+	0x6e, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x54, 0x4c,
 	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0xfd, 0x02, 0x0a, 0x04, 0x44,
-	0x61, 0x74, 0x61, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
-	0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x72, 0x65,
-	0x64, 0x69, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x72, 0x61, 0x74,
-	0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x52, 0x65, 0x64, 0x69,
-	0x73, 0x52, 0x05, 0x72, 0x65, 0x64, 0x69, 0x73, 0x1a, 0x3a, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61,
-	0x62, 0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x1a, 0xd3, 0x01, 0x0a, 0x05, 0x52, 0x65, 0x64, 0x69, 0x73, 0x12, 0x12,
-	0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64,
-	0x64, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x61, 0x78, 0x49, 0x64, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x07, 0x6d, 0x61, 0x78, 0x49, 0x64, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x64, 0x6c, 0x65,
-	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x69,
-	0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61,
-	0x78, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d,
-	0x61, 0x78, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x11,
-	0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
-	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x69, 0x0a, 0x09, 0x45, 0x6d,
-	0x61, 0x69, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65,
-	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x2c, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x69, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x11, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
-	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x22, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
-	0x79, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x22, 0xa4, 0x03, 0x0a, 0x07, 0x4c, 0x6f,
-	0x67, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x1e, 0x0a,
-	0x0a, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0a, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x20, 0x0a,
-	0x0b, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0b, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x12,
-	0x30, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
-	0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x43, 0x6f,
-	0x6e, 0x66, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x04, 0x66, 0x69, 0x6c,
-	0x65, 0x12, 0x33, 0x0a, 0x05, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1d, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f,
-	0x67, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x52,
-	0x05, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x1a, 0xa0, 0x01, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x43,
-	0x6f, 0x6e, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61,
-	0x78, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b,
-	0x75, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x42, 0x61,
-	0x63, 0x6b, 0x75, 0x70, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x08, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x1a, 0x35, 0x0a, 0x09, 0x4b, 0x61, 0x66,
-	0x6b, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
-	0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63,
-	0x42, 0x19, 0x5a, 0x17, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
-	0x6c, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x3b, 0x63, 0x6f, 0x6e, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x6e, 0x52, 0x09, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x54, 0x4c, 0x12, 0x39, 0x0a, 0x0a,
+	0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x54, 0x4c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x54, 0x54, 0x4c, 0x12, 0x2d, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x4b, 0x65, 0x79,
+	0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x36, 0x0a, 0x16, 0x69, 0x6e, 0x74, 0x72, 0x6f, 0x73,
+	0x70, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x16, 0x69, 0x6e, 0x74, 0x72, 0x6f, 0x73, 0x70, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x4f,
+	0x0a, 0x15, 0x69, 0x6e, 0x74, 0x72, 0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x54, 0x54, 0x4c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x15, 0x69, 0x6e, 0x74, 0x72, 0x6f, 0x73,
+	0x70, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x54, 0x54, 0x4c, 0x1a,
+	0x87, 0x02, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72,
+	0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f,
+	0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x26, 0x0a,
+	0x0e, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65,
+	0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65,
+	0x66, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65, 0x66, 0x6f, 0x72,
+	0x65, 0x12, 0x36, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x08, 0x6e, 0x6f, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0x22, 0x0a, 0x0c, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x22, 0xa4, 0x03,
+	0x0a, 0x07, 0x4c, 0x6f, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64,
+	0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4b, 0x61, 0x66, 0x6b, 0x61,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4b, 0x61,
+	0x66, 0x6b, 0x61, 0x12, 0x30, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c,
+	0x6f, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x52,
+	0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x43,
+	0x6f, 0x6e, 0x66, 0x52, 0x05, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x1a, 0xa0, 0x01, 0x0a, 0x08, 0x46,
+	0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78,
+	0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d,
+	0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x78,
+	0x41, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x1a, 0x35, 0x0a,
+	0x09, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64,
+	0x64, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x6f, 0x70, 0x69, 0x63, 0x22, 0xe9, 0x02, 0x0a, 0x0b, 0x4c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x12, 0x2a, 0x0a, 0x10, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x12, 0x20, 0x0a, 0x0b, 0x69, 0x70, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x69, 0x70, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x77,
+	0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x45, 0x0a, 0x10, 0x62, 0x61, 0x73, 0x65, 0x4c, 0x6f, 0x63,
+	0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x62, 0x61, 0x73, 0x65,
+	0x4c, 0x6f, 0x63, 0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x43, 0x0a, 0x0f,
+	0x6d, 0x61, 0x78, 0x4c, 0x6f, 0x63, 0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0f, 0x6d, 0x61, 0x78, 0x4c, 0x6f, 0x63, 0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x4d, 0x0a, 0x14, 0x65, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x65, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x14, 0x65, 0x73, 0x63, 0x61,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72,
+	0x22, 0xdc, 0x01, 0x0a, 0x0d, 0x54, 0x77, 0x6f, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x43, 0x6f,
+	0x6e, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6b,
+	0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x6b, 0x65, 0x77, 0x12, 0x2c,
+	0x0a, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3d, 0x0a, 0x0c,
+	0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x54, 0x54, 0x4c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x63,
+	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x54, 0x54, 0x4c, 0x12, 0x32, 0x0a, 0x14, 0x63,
+	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x4d, 0x61, 0x78, 0x41, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14, 0x63, 0x68, 0x61, 0x6c, 0x6c,
+	0x65, 0x6e, 0x67, 0x65, 0x4d, 0x61, 0x78, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x22,
+	0x88, 0x02, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x43, 0x6f,
+	0x6e, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x2b, 0x0a, 0x03, 0x74, 0x74,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x41, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61,
+	0x78, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x63, 0x6f, 0x6f,
+	0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x63, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e,
+	0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x61,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x6f,
+	0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x65,
+	0x78, 0x70, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x22, 0xed, 0x02, 0x0a, 0x0a, 0x4f,
+	0x75, 0x74, 0x62, 0x6f, 0x78, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x3d, 0x0a, 0x0c, 0x70, 0x6f, 0x6c,
+	0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x70, 0x6f, 0x6c, 0x6c,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x41, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78,
+	0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x62, 0x61, 0x73, 0x65,
+	0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x62, 0x61, 0x73, 0x65, 0x42, 0x61,
+	0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x39, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b,
+	0x6f, 0x66, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66,
+	0x12, 0x2f, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x12, 0x37, 0x0a, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x53, 0x0a, 0x07, 0x53, 0x6d,
+	0x73, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12,
+	0x2e, 0x0a, 0x12, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x22,
+	0xc2, 0x02, 0x0a, 0x06, 0x49, 0x44, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x1c, 0x0a, 0x09, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x30, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x35, 0x0a, 0x08, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x54, 0x54, 0x4c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x54,
+	0x4c, 0x12, 0x45, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x61, 0x63,
+	0x6b, 0x77, 0x61, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x6d, 0x61, 0x78, 0x43, 0x6c, 0x6f, 0x63, 0x6b,
+	0x42, 0x61, 0x63, 0x6b, 0x77, 0x61, 0x72, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x53, 0x74, 0x65, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x65, 0x70, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x69, 0x7a, 0x54, 0x61, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x69, 0x7a, 0x54, 0x61, 0x67,
+	0x12, 0x22, 0x0a, 0x0c, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x22, 0x26, 0x0a, 0x0c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49, 0x44,
+	0x43, 0x6f, 0x6e, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0xa8, 0x02, 0x0a,
+	0x09, 0x43, 0x61, 0x63, 0x68, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f,
+	0x61, 0x64, 0x4c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6c, 0x6f,
+	0x61, 0x64, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x3b, 0x0a, 0x0b, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x6f,
+	0x63, 0x6b, 0x54, 0x54, 0x4c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x6f, 0x63, 0x6b,
+	0x54, 0x54, 0x4c, 0x12, 0x35, 0x0a, 0x08, 0x6c, 0x6f, 0x61, 0x64, 0x57, 0x61, 0x69, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x08, 0x6c, 0x6f, 0x61, 0x64, 0x57, 0x61, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x35,
+	0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x54, 0x54, 0x4c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x54, 0x54, 0x4c, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x6f,
+	0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x42, 0x19, 0x5a, 0x17, 0x75, 0x73, 0x65, 0x72, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x3b, 0x63, 0x6f,
+	0x6e, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -833,38 +2507,94 @@ func file_conf_conf_proto_rawDescGZIP() []byte {
 	return file_conf_conf_proto_rawDescData
 }
 
-var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_conf_conf_proto_goTypes = []any{
-	(*Bootstrap)(nil),           // 0: kratos.api.Bootstrap
-	(*Server)(nil),              // 1: kratos.api.Server
-	(*Data)(nil),                // 2: kratos.api.Data
-	(*EmailConf)(nil),           // 3: kratos.api.EmailConf
-	(*RegistryConf)(nil),        // 4: kratos.api.RegistryConf
-	(*LogConf)(nil),             // 5: kratos.api.LogConf
-	(*Server_GRPC)(nil),         // 6: kratos.api.Server.GRPC
-	(*Data_Database)(nil),       // 7: kratos.api.Data.Database
-	(*Data_Redis)(nil),          // 8: kratos.api.Data.Redis
-	(*LogConf_FileConf)(nil),    // 9: kratos.api.LogConf.FileConf
-	(*LogConf_KafkaConf)(nil),   // 10: kratos.api.LogConf.KafkaConf
-	(*durationpb.Duration)(nil), // 11: google.protobuf.Duration
+	(*Bootstrap)(nil),             // 0: kratos.api.Bootstrap
+	(*Server)(nil),                // 1: kratos.api.Server
+	(*Data)(nil),                  // 2: kratos.api.Data
+	(*EmailConf)(nil),             // 3: kratos.api.EmailConf
+	(*PasswordConf)(nil),          // 4: kratos.api.PasswordConf
+	(*TokenConf)(nil),             // 5: kratos.api.TokenConf
+	(*RegistryConf)(nil),          // 6: kratos.api.RegistryConf
+	(*LogConf)(nil),               // 7: kratos.api.LogConf
+	(*LockoutConf)(nil),           // 8: kratos.api.LockoutConf
+	(*TwoFactorConf)(nil),         // 9: kratos.api.TwoFactorConf
+	(*VerifyCodeConf)(nil),        // 10: kratos.api.VerifyCodeConf
+	(*OutboxConf)(nil),            // 11: kratos.api.OutboxConf
+	(*SmsConf)(nil),               // 12: kratos.api.SmsConf
+	(*IDConf)(nil),                // 13: kratos.api.IDConf
+	(*PublicIDConf)(nil),          // 14: kratos.api.PublicIDConf
+	(*CacheConf)(nil),             // 15: kratos.api.CacheConf
+	(*Server_HTTP)(nil),           // 16: kratos.api.Server.HTTP
+	(*Server_GRPC)(nil),           // 17: kratos.api.Server.GRPC
+	(*Data_Database)(nil),         // 18: kratos.api.Data.Database
+	(*Data_Redis)(nil),            // 19: kratos.api.Data.Redis
+	(*EmailConf_SMTP)(nil),        // 20: kratos.api.EmailConf.SMTP
+	(*PasswordConf_Argon2)(nil),   // 21: kratos.api.PasswordConf.Argon2
+	(*PasswordConf_Policy)(nil),   // 22: kratos.api.PasswordConf.Policy
+	(*TokenConf_Key)(nil),         // 23: kratos.api.TokenConf.Key
+	(*LogConf_FileConf)(nil),      // 24: kratos.api.LogConf.FileConf
+	(*LogConf_KafkaConf)(nil),     // 25: kratos.api.LogConf.KafkaConf
+	(*durationpb.Duration)(nil),   // 26: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 27: google.protobuf.Timestamp
 }
 var file_conf_conf_proto_depIdxs = []int32{
 	1,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
 	2,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
 	3,  // 2: kratos.api.Bootstrap.email:type_name -> kratos.api.EmailConf
-	4,  // 3: kratos.api.Bootstrap.registry:type_name -> kratos.api.RegistryConf
-	5,  // 4: kratos.api.Bootstrap.log:type_name -> kratos.api.LogConf
-	6,  // 5: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	7,  // 6: kratos.api.Data.database:type_name -> kratos.api.Data.Database
-	8,  // 7: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	9,  // 8: kratos.api.LogConf.file:type_name -> kratos.api.LogConf.FileConf
-	10, // 9: kratos.api.LogConf.kafka:type_name -> kratos.api.LogConf.KafkaConf
-	11, // 10: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	6,  // 3: kratos.api.Bootstrap.registry:type_name -> kratos.api.RegistryConf
+	7,  // 4: kratos.api.Bootstrap.log:type_name -> kratos.api.LogConf
+	4,  // 5: kratos.api.Bootstrap.password:type_name -> kratos.api.PasswordConf
+	5,  // 6: kratos.api.Bootstrap.token:type_name -> kratos.api.TokenConf
+	8,  // 7: kratos.api.Bootstrap.lockout:type_name -> kratos.api.LockoutConf
+	9,  // 8: kratos.api.Bootstrap.twoFactor:type_name -> kratos.api.TwoFactorConf
+	10, // 9: kratos.api.Bootstrap.verifyCode:type_name -> kratos.api.VerifyCodeConf
+	11, // 10: kratos.api.Bootstrap.outbox:type_name -> kratos.api.OutboxConf
+	12, // 11: kratos.api.Bootstrap.sms:type_name -> kratos.api.SmsConf
+	13, // 12: kratos.api.Bootstrap.id:type_name -> kratos.api.IDConf
+	14, // 13: kratos.api.Bootstrap.publicID:type_name -> kratos.api.PublicIDConf
+	15, // 14: kratos.api.Bootstrap.cache:type_name -> kratos.api.CacheConf
+	17, // 15: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	16, // 16: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	18, // 17: kratos.api.Data.database:type_name -> kratos.api.Data.Database
+	19, // 18: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
+	20, // 19: kratos.api.EmailConf.smtp:type_name -> kratos.api.EmailConf.SMTP
+	21, // 20: kratos.api.PasswordConf.argon2:type_name -> kratos.api.PasswordConf.Argon2
+	22, // 21: kratos.api.PasswordConf.policy:type_name -> kratos.api.PasswordConf.Policy
+	26, // 22: kratos.api.TokenConf.accessTTL:type_name -> google.protobuf.Duration
+	26, // 23: kratos.api.TokenConf.refreshTTL:type_name -> google.protobuf.Duration
+	23, // 24: kratos.api.TokenConf.keys:type_name -> kratos.api.TokenConf.Key
+	26, // 25: kratos.api.TokenConf.introspectionCacheTTL:type_name -> google.protobuf.Duration
+	24, // 26: kratos.api.LogConf.file:type_name -> kratos.api.LogConf.FileConf
+	25, // 27: kratos.api.LogConf.kafka:type_name -> kratos.api.LogConf.KafkaConf
+	26, // 28: kratos.api.LockoutConf.window:type_name -> google.protobuf.Duration
+	26, // 29: kratos.api.LockoutConf.baseLockDuration:type_name -> google.protobuf.Duration
+	26, // 30: kratos.api.LockoutConf.maxLockDuration:type_name -> google.protobuf.Duration
+	26, // 31: kratos.api.LockoutConf.escalationResetAfter:type_name -> google.protobuf.Duration
+	26, // 32: kratos.api.TwoFactorConf.challengeTTL:type_name -> google.protobuf.Duration
+	26, // 33: kratos.api.VerifyCodeConf.ttl:type_name -> google.protobuf.Duration
+	26, // 34: kratos.api.VerifyCodeConf.cooldown:type_name -> google.protobuf.Duration
+	26, // 35: kratos.api.OutboxConf.pollInterval:type_name -> google.protobuf.Duration
+	26, // 36: kratos.api.OutboxConf.baseBackoff:type_name -> google.protobuf.Duration
+	26, // 37: kratos.api.OutboxConf.maxBackoff:type_name -> google.protobuf.Duration
+	26, // 38: kratos.api.OutboxConf.lease:type_name -> google.protobuf.Duration
+	26, // 39: kratos.api.OutboxConf.retention:type_name -> google.protobuf.Duration
+	27, // 40: kratos.api.IDConf.epoch:type_name -> google.protobuf.Timestamp
+	26, // 41: kratos.api.IDConf.leaseTTL:type_name -> google.protobuf.Duration
+	26, // 42: kratos.api.IDConf.maxClockBackward:type_name -> google.protobuf.Duration
+	26, // 43: kratos.api.CacheConf.loadLockTTL:type_name -> google.protobuf.Duration
+	26, // 44: kratos.api.CacheConf.loadWait:type_name -> google.protobuf.Duration
+	26, // 45: kratos.api.CacheConf.localTTL:type_name -> google.protobuf.Duration
+	26, // 46: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	26, // 47: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	26, // 48: kratos.api.EmailConf.SMTP.timeout:type_name -> google.protobuf.Duration
+	27, // 49: kratos.api.TokenConf.Key.notBefore:type_name -> google.protobuf.Timestamp
+	27, // 50: kratos.api.TokenConf.Key.notAfter:type_name -> google.protobuf.Timestamp
+	51, // [51:51] is the sub-list for method output_type
+	51, // [51:51] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_conf_conf_proto_init() }
@@ -878,7 +2608,7 @@ func file_conf_conf_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_conf_conf_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	cache2 "github.com/TiktokCommence/userService/internal/foundation/cache"
+	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"github.com/TiktokCommence/userService/internal/foundation/token"
+	"github.com/TiktokCommence/userService/internal/model"
+	"time"
+)
+
+var _ biz.ChallengeWorker = (*ChallengeWorker)(nil)
+
+const (
+	DefaultChallengeTTL         = 5 * time.Minute
+	DefaultChallengeMaxAttempts = 5
+)
+
+type ChallengeWorker struct {
+	c           common.Cache
+	ttl         time.Duration
+	maxAttempts int64
+}
+
+func NewChallengeWorker(c common.Cache, tc *conf.TwoFactorConf) *ChallengeWorker {
+	w := &ChallengeWorker{
+		c:           c,
+		ttl:         tc.GetChallengeTTL().AsDuration(),
+		maxAttempts: tc.GetChallengeMaxAttempts(),
+	}
+	if w.ttl <= 0 {
+		w.ttl = DefaultChallengeTTL
+	}
+	if w.maxAttempts <= 0 {
+		w.maxAttempts = DefaultChallengeMaxAttempts
+	}
+	return w
+}
+
+func (w *ChallengeWorker) CreateChallenge(ctx context.Context, userID uint64, client model.ClientInfo) (model.MFAChallenge, error) {
+	id, err := token.RandomString(24)
+	if err != nil {
+		return model.MFAChallenge{}, err
+	}
+	challenge := model.MFAChallenge{ID: id, UserID: userID, Client: client, ExpiresAt: time.Now().Add(w.ttl)}
+	val, err := challenge.Write()
+	if err != nil {
+		return model.MFAChallenge{}, err
+	}
+	if err = w.c.SetEx(ctx, w.generateKey(id), val, int64(w.ttl/time.Second)); err != nil {
+		return model.MFAChallenge{}, fmt.Errorf("create login challenge for user %d err:%w", userID, err)
+	}
+	return challenge, nil
+}
+
+func (w *ChallengeWorker) GetChallenge(ctx context.Context, id string) (model.MFAChallenge, error) {
+	val, err := w.c.Get(ctx, w.generateKey(id))
+	if errors.Is(err, cache2.ErrorCacheMiss) {
+		return model.MFAChallenge{}, errcode.ChallengeInvalid
+	}
+	if err != nil {
+		return model.MFAChallenge{}, err
+	}
+	var challenge model.MFAChallenge
+	if err = challenge.Read(val); err != nil {
+		return model.MFAChallenge{}, err
+	}
+	return challenge, nil
+}
+
+// ChallengeFailed 记录一次二次验证失败，失败次数用尽后挑战作废，需要重新登录
+func (w *ChallengeWorker) ChallengeFailed(ctx context.Context, id string) error {
+	attemptsKey := w.generateAttemptsKey(id)
+	attempts, err := w.c.IncrBy(ctx, attemptsKey, 1)
+	if err != nil {
+		return err
+	}
+	if attempts == 1 {
+		if err = w.c.Expire(ctx, attemptsKey, int64(w.ttl/time.Second)); err != nil {
+			return err
+		}
+	}
+	if attempts >= w.maxAttempts {
+		return w.c.Del(ctx, w.generateKey(id))
+	}
+	return nil
+}
+
+// ConsumeChallenge 二次验证通过后作废挑战，保证同一个挑战只能换取一次令牌
+func (w *ChallengeWorker) ConsumeChallenge(ctx context.Context, id string) error {
+	_, err := w.c.GetDel(ctx, w.generateKey(id))
+	if errors.Is(err, cache2.ErrorCacheMiss) {
+		return errcode.ChallengeInvalid
+	}
+	if err != nil {
+		return err
+	}
+	return w.c.Del(ctx, w.generateAttemptsKey(id))
+}
+
+func (w *ChallengeWorker) generateKey(id string) string {
+	return fmt.Sprintf("mfa_challenge:%s", id)
+}
+
+func (w *ChallengeWorker) generateAttemptsKey(id string) string {
+	return fmt.Sprintf("mfa_challenge_attempts:%s", id)
+}
@@ -5,16 +5,22 @@ import (
 	"github.com/TiktokCommence/userService/internal/conf"
 	DB2 "github.com/TiktokCommence/userService/internal/foundation/DB"
 	cache2 "github.com/TiktokCommence/userService/internal/foundation/cache"
+	"github.com/TiktokCommence/userService/internal/foundation/clientip"
 	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"github.com/TiktokCommence/userService/internal/foundation/password"
+	"github.com/TiktokCommence/userService/internal/foundation/publicid"
+	"github.com/TiktokCommence/userService/internal/foundation/token"
 	"github.com/TiktokCommence/userService/internal/model"
 	"github.com/google/wire"
 )
 
 // ProviderSet is data providers.
-var ProviderSet = wire.NewSet(NewDB, NewCache, NewOptions, NewUserRepo, NewEmailWorker, NewRedisWorkerImplement)
+var ProviderSet = wire.NewSet(NewDB, NewCache, NewOptions, NewUserRepo, NewVerifyCodeStore, NewMailer, NewMailRenderer, NewOutbox, NewEmailWorker, NewRedisWorkerImplement, NewPasswordHasher,
+	NewTokenManager, NewTokenWorker, NewSessionWorker, NewIntrospectionCache, NewPasswordPolicy, NewLoginLimiter,
+	NewTwoFactorRepo, NewTOTPWorker, NewChallengeWorker, NewSmsSender, NewSmsWorker, NewEmailChangeWorker, NewEmailCanonicalizer, NewIDGenerator, NewPublicIDCodec, NewClientIPResolver, NewLoadLock, NewLocalUserCache)
 
 func NewDB(data *conf.Data) (common.DB, error) {
-	tables := []interface{}{&model.User{}}
+	tables := []interface{}{&model.User{}, &model.TwoFactor{}, &model.OutboxMessage{}, &model.EmailChange{}, &model.IDSegment{}}
 	return DB2.NewDB(&DB2.Config{Tables: tables, Dsn: data.Database.Source}, DB2.WithDuplicateEntry(false))
 }
 func NewCache(c *conf.Data) common.Cache {
@@ -41,6 +47,62 @@ func NewOptions(c *conf.Data) *cache2.Options {
 	return options
 }
 
+func NewPasswordHasher(c *conf.PasswordConf) (*password.Manager, error) {
+	argon := c.GetArgon2()
+	return password.NewManager(c.GetAlgorithm(),
+		password.WithBcryptCost(int(c.GetBcryptCost())),
+		password.WithArgon2Params(password.Argon2Params{
+			Memory:      argon.GetMemory(),
+			Iterations:  argon.GetIterations(),
+			Parallelism: uint8(argon.GetParallelism()),
+			SaltLength:  argon.GetSaltLength(),
+			KeyLength:   argon.GetKeyLength(),
+		}),
+	)
+}
+
+func NewTokenManager(c *conf.TokenConf) (*token.Manager, error) {
+	keys := make([]*token.Key, 0, len(c.GetKeys()))
+	for _, k := range c.GetKeys() {
+		kc := &token.KeyConfig{
+			Kid:            k.GetKid(),
+			Algorithm:      k.GetAlgorithm(),
+			Secret:         k.GetSecret(),
+			PrivateKey:     k.GetPrivateKey(),
+			PrivateKeyFile: k.GetPrivateKeyFile(),
+		}
+		if k.GetNotBefore() != nil {
+			kc.NotBefore = k.GetNotBefore().AsTime()
+		}
+		if k.GetNotAfter() != nil {
+			kc.NotAfter = k.GetNotAfter().AsTime()
+		}
+		key, err := token.NewKey(kc)
+		if err != nil {
+			return nil, fmt.Errorf("load signing key %q err:%w", k.GetKid(), err)
+		}
+		keys = append(keys, key)
+	}
+	ks, err := token.NewKeySet(keys...)
+	if err != nil {
+		return nil, err
+	}
+	return token.NewManager(ks,
+		token.WithIssuer(c.GetIssuer()),
+		token.WithAudience(c.GetAudience()),
+		token.WithAccessTTL(c.GetAccessTTL().AsDuration()),
+		token.WithRefreshTTL(c.GetRefreshTTL().AsDuration()),
+	), nil
+}
+
+func NewPublicIDCodec(c *conf.PublicIDConf) (*publicid.Codec, error) {
+	return publicid.New([]byte(c.GetSecret()))
+}
+
+func NewClientIPResolver(c *conf.Server) (*clientip.Resolver, error) {
+	return clientip.New(c.GetTrustedProxies())
+}
+
 func GenerateKey(id uint64) string {
 	return fmt.Sprintf("user:%d", id)
 }
@@ -10,21 +10,30 @@ import (
 	"github.com/TiktokCommence/userService/internal/foundation/common"
 	"github.com/TiktokCommence/userService/internal/model"
 	"github.com/go-kratos/kratos/v2/log"
+	"strings"
+	"time"
 )
 
 var _ biz.DBWorker = (*UserRepo)(nil)
 
+// UserRepo 读写邮箱时都先转换为规范形式，调用方传入的邮箱写法不影响匹配结果
 type UserRepo struct {
 	d common.DB
+	c *EmailCanonicalizer
 	h *log.Helper
 }
 
-func NewUserRepo(d common.DB, logger log.Logger) *UserRepo {
-	return &UserRepo{d: d, h: log.NewHelper(logger)}
+func NewUserRepo(d common.DB, c *EmailCanonicalizer, logger log.Logger) *UserRepo {
+	return &UserRepo{d: d, c: c, h: log.NewHelper(logger)}
 }
 
 func (D *UserRepo) CreateUser(ctx context.Context, user model.User) error {
-	err := D.d.Put(ctx, &user)
+	email, err := D.c.Canonicalize(user.Email)
+	if err != nil {
+		return err
+	}
+	user.Email = email
+	err = D.d.Put(ctx, &user)
 	defer func() {
 		if err != nil {
 			D.h.Errorf("put user{%v} to db error {%v}", user, err)
@@ -50,32 +59,140 @@ func (D *UserRepo) GetUserByID(ctx context.Context, id uint64) (model.User, erro
 	return user, err
 }
 
-func (D *UserRepo) SaveUserInfo(ctx context.Context, user model.User) error {
-	err := D.d.Update(ctx, &user)
-	defer func() {
-		if err != nil {
-			D.h.Errorf("update user{%v} to db error {%v}", user, err)
+// UpdateProfile 只更新请求中设置了的资料列，不会覆盖密码、邮箱等其他流程修改的列
+func (D *UserRepo) UpdateProfile(ctx context.Context, id uint64, p model.ProfileUpdate) error {
+	columns := p.Columns()
+	if len(columns) == 0 {
+		ok, err := D.d.Exist(ctx, &model.User{}, map[string]interface{}{"id": id})
+		if err == nil && !ok {
+			return errcode.UserNotFound
 		}
-	}()
-	return err
+		return err
+	}
+	columns["updated_at"] = time.Now()
+	n, err := D.d.UpdateColumns(ctx, &model.User{}, "id = ?", []interface{}{id}, columns)
+	if err != nil {
+		D.h.Errorf("update profile of user %d error {%v}", id, err)
+		return err
+	}
+	if n == 0 {
+		return errcode.UserNotFound
+	}
+	return nil
+}
+
+// UpdatePassword 只有当前的密码哈希仍是 old 时才修改，返回是否修改成功
+func (D *UserRepo) UpdatePassword(ctx context.Context, id uint64, old, hashed string) (bool, error) {
+	n, err := D.d.UpdateColumns(ctx, &model.User{}, "id = ? AND password = ?", []interface{}{id, old},
+		map[string]interface{}{"password": hashed, "updated_at": time.Now()})
+	if err != nil {
+		D.h.Errorf("update password of user %d error {%v}", id, err)
+		return false, err
+	}
+	return n == 1, nil
 }
 
 func (D *UserRepo) CheckEmailExist(ctx context.Context, email string) bool {
-	ok, _ := D.d.Exist(ctx, &model.User{}, map[string]interface{}{
-		"email": email,
-	})
-	return ok
+	_, err := D.GetUserByEmail(ctx, email)
+	return err == nil
 }
 
+// GetUserByEmail 先按规范形式查询，未命中时再按调用方的原始写法及其小写形式查询。
+// 执行 emailmigrate -apply 之前，存量邮箱可能不是当前规则下的规范形式
 func (D *UserRepo) GetUserByEmail(ctx context.Context, email string) (model.User, error) {
 	var user model.User
-	err := D.d.Query(ctx, &user, map[string]interface{}{
-		"email": email,
+	canonical, err := D.c.Canonicalize(email)
+	if err != nil {
+		return user, errcode.UserNotFound
+	}
+	err = D.d.Query(ctx, &user, map[string]interface{}{
+		"email": canonical,
 	})
-	if errors.Is(err, DB.ErrorDBMiss) {
+	if !errors.Is(err, DB.ErrorDBMiss) {
+		return user, err
+	}
+	raw := strings.TrimSpace(email)
+	if raw == canonical {
 		return user, errcode.UserNotFound
 	}
-	return user, err
+	var users []model.User
+	err = D.d.Find(ctx, &model.User{}, &users, "email IN ?", []interface{}{[]string{raw, strings.ToLower(raw)}}, "id", 1)
+	if err != nil {
+		return model.User{}, err
+	}
+	if len(users) == 0 {
+		return model.User{}, errcode.UserNotFound
+	}
+	return users[0], nil
+}
+
+// GetUserByPhone 按已验证的手机号查询用户，未验证的手机号不能用于定位用户
+func (D *UserRepo) GetUserByPhone(ctx context.Context, phone string) (model.User, error) {
+	var users []model.User
+	err := D.d.Find(ctx, &model.User{}, &users, "verified_phone = ?", []interface{}{phone}, "id", 1)
+	if err != nil {
+		return model.User{}, err
+	}
+	if len(users) == 0 {
+		return model.User{}, errcode.UserNotFound
+	}
+	return users[0], nil
+}
+
+// UpdatePhone 修改手机号及其验证时间，phone 为 nil 表示解绑，verifiedAt 为 nil 表示未验证，
+// 号码已被其他用户验证时由唯一索引拒绝，返回 errcode.PhoneAlreadyUsed
+func (D *UserRepo) UpdatePhone(ctx context.Context, id uint64, phone *string, verifiedAt *time.Time) error {
+	var verified *string
+	if verifiedAt != nil {
+		verified = phone
+	}
+	_, err := D.d.UpdateColumns(ctx, &model.User{}, "id = ?", []interface{}{id}, map[string]interface{}{
+		"phone":             phone,
+		"phone_verified_at": verifiedAt,
+		"verified_phone":    verified,
+	})
+	if DB.IsDuplicateEntryErr(err) {
+		return errcode.PhoneAlreadyUsed
+	}
+	if err != nil {
+		D.h.Errorf("update phone of user %d error {%v}", id, err)
+	}
+	return err
+}
+
+// ChangeEmail 在事务中检查新邮箱未被占用后修改邮箱并写入审计记录，
+// 只有当前邮箱仍为 oldEmail 时才修改，避免并发修改互相覆盖
+func (D *UserRepo) ChangeEmail(ctx context.Context, id uint64, oldEmail string, audit model.EmailChange) error {
+	newEmail, err := D.c.Canonicalize(audit.NewEmail)
+	if err != nil {
+		return err
+	}
+	audit.NewEmail = newEmail
+	err = D.d.Transaction(ctx, func(tx common.DB) error {
+		repo := &UserRepo{d: tx, c: D.c, h: D.h}
+		if repo.CheckEmailExist(ctx, audit.NewEmail) {
+			return errcode.UserAlreadyExists
+		}
+		n, err := tx.UpdateColumns(ctx, &model.User{}, "id = ? AND email = ?", []interface{}{id, oldEmail}, map[string]interface{}{
+			"email": audit.NewEmail,
+		})
+		if DB.IsDuplicateEntryErr(err) {
+			return errcode.UserAlreadyExists
+		}
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return errcode.UserNotFound
+		}
+		audit.UserID = id
+		audit.OldEmail = oldEmail
+		return tx.Put(ctx, &audit)
+	})
+	if err != nil && !errors.Is(err, errcode.UserAlreadyExists) {
+		D.h.Errorf("change email of user %d error {%v}", id, err)
+	}
+	return err
 }
 
 func (D *UserRepo) DeleteUser(ctx context.Context, id uint64) error {
@@ -20,7 +20,7 @@ func initUserRepo() (*UserRepo, error) {
 		return nil, err
 	}
 	logger := log.NewStdLogger(os.Stdout)
-	return NewUserRepo(db, logger), nil
+	return NewUserRepo(db, NewEmailCanonicalizer(&conf.EmailConf{}), logger), nil
 }
 
 func TestUserRepo_CreateUser(t *testing.T) {
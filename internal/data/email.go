@@ -2,70 +2,190 @@ package data
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/TiktokCommence/userService/internal/biz"
 	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/errcode"
 	"github.com/TiktokCommence/userService/internal/foundation/common"
-	email2 "github.com/jordan-wright/email"
-	"math/rand"
-	"net/smtp"
+	"github.com/TiktokCommence/userService/internal/foundation/mail"
+	"github.com/TiktokCommence/userService/internal/foundation/mailtpl"
+	"github.com/TiktokCommence/userService/internal/foundation/verifycode"
+	"github.com/TiktokCommence/userService/internal/model"
+	"os"
+	"strconv"
 	"time"
 )
 
 var _ biz.EmailWorker = (*EmailWorker)(nil)
 
-const charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+const (
+	MailBackendSMTP   = "smtp"
+	MailBackendFile   = "file"
+	MailBackendStdout = "stdout"
+	MailBackendMemory = "memory"
+)
 
 type EmailWorker struct {
-	c  common.Cache
-	cf *conf.EmailConf
+	s *verifycode.Store
+	m mail.Mailer
+	t *mailtpl.Renderer
+}
+
+// NewMailer 按配置选择邮件发送方式，file、stdout 用于本地开发，memory 用于测试
+func NewMailer(c *conf.EmailConf) (mail.Mailer, error) {
+	switch c.GetBackend() {
+	case "", MailBackendSMTP:
+		host := c.GetSmtp().GetHost()
+		if host == "" {
+			host = "smtp.qq.com"
+		}
+		return mail.NewSMTPMailer(mail.SMTPConfig{
+			Host:     host,
+			Port:     int(c.GetSmtp().GetPort()),
+			Username: c.GetSmtp().GetUsername(),
+			Password: c.GetSecret(),
+			From:     c.GetSender(),
+			Security: c.GetSmtp().GetSecurity(),
+			Timeout:  c.GetSmtp().GetTimeout().AsDuration(),
+		})
+	case MailBackendFile:
+		if c.GetFilePath() == "" {
+			return nil, errors.New("email filePath is required by file backend")
+		}
+		return mail.NewFileMailer(c.GetFilePath()), nil
+	case MailBackendStdout:
+		return mail.NewWriterMailer(os.Stdout), nil
+	case MailBackendMemory:
+		return mail.NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("unsupported email backend %q", c.GetBackend())
+	}
 }
 
-func NewEmailWorker(c common.Cache, cf *conf.EmailConf) *EmailWorker {
-	return &EmailWorker{c: c, cf: cf}
+func NewVerifyCodeStore(c common.Cache, vc *conf.VerifyCodeConf, ec *conf.EmailConf) *verifycode.Store {
+	ttl := vc.GetTtl().AsDuration()
+	if vc.GetTtl() == nil {
+		ttl = time.Duration(ec.GetExpirationSeconds()) * time.Second
+	}
+	opts := []verifycode.Option{
+		verifycode.WithLength(int(vc.GetLength())),
+		verifycode.WithTTL(ttl),
+		verifycode.WithMaxAttempts(vc.GetMaxAttempts()),
+		verifycode.WithDailyQuota(vc.GetDailyQuota()),
+		verifycode.WithSecret(vc.GetSecret()),
+		verifycode.WithExposeCodes(vc.GetExposeCodes()),
+	}
+	if vc.GetCooldown() != nil {
+		opts = append(opts, verifycode.WithCooldown(vc.GetCooldown().AsDuration()))
+	}
+	return verifycode.NewStore(c, opts...)
+}
+
+// NewEmailWorker 验证码邮件写入发件箱后即返回，由后台任务异步发送
+func NewEmailWorker(s *verifycode.Store, o *Outbox, t *mailtpl.Renderer) *EmailWorker {
+	return &EmailWorker{s: s, m: o, t: t}
+}
+
+// NewMailRenderer 加载内置邮件模板，配置了 templateDir 时用其中的同名模板覆盖
+func NewMailRenderer(c *conf.EmailConf) (*mailtpl.Renderer, error) {
+	return mailtpl.New(c.GetTemplateDir(), c.GetDefaultLocale())
+}
+
+// VerifyCode 校验对应用途的验证码，校验通过或失败次数用尽后验证码失效
+func (e *EmailWorker) VerifyCode(ctx context.Context, purpose, email, code string) error {
+	err := e.s.Verify(ctx, purpose, email, code)
+	if errors.Is(err, verifycode.ErrorCodeInvalid) {
+		return errcode.VerifyCodeInvalid
+	}
+	if errors.Is(err, verifycode.ErrorTooManyAttempts) {
+		return errcode.VerifyCodeAttemptsExceeded
+	}
+	return err
 }
 
-func (e *EmailWorker) VerifyEmailCode(ctx context.Context, email, code string) bool {
-	value, err := e.c.Get(ctx, e.generateKey(email))
+// SendCode 生成对应用途的验证码并发送到邮箱，返回距离下一次可以发送的时长，
+// 邮件没有成功发送或入队时验证码立即作废并返回错误
+func (e *EmailWorker) SendCode(ctx context.Context, purpose, email string) (time.Duration, error) {
+	code, err := e.s.Issue(ctx, purpose, email)
+	var cooldown *verifycode.CooldownError
+	if errors.As(err, &cooldown) {
+		return 0, &errcode.CooldownError{RetryAfter: cooldown.Remaining}
+	}
+	if errors.Is(err, verifycode.ErrorQuotaExceeded) {
+		return 0, errcode.VerifyCodeQuotaExceeded
+	}
 	if err != nil {
-		return false
+		return 0, err
 	}
-	if value == code {
-		defer func() {
-			e.c.Del(ctx, e.generateKey(email))
-		}()
-		return true
+	msg, err := e.render(ctx, "code_"+purpose, email, map[string]string{
+		"Code":    code,
+		"Minutes": strconv.FormatInt(int64(e.s.Options().TTL/time.Minute), 10),
+	})
+	if err == nil {
+		// 同一个验证码的邮件只会入队一次
+		msg.IdempotencyKey = idempotencyKey(purpose, email, code)
+		// 验证码过期后邮件不再发送
+		msg.ExpiresAt = time.Now().Add(e.s.Options().TTL)
+		err = e.m.Send(ctx, msg)
+	}
+	if err != nil {
+		if err1 := e.s.Revoke(ctx, purpose, email); err1 != nil {
+			err = errors.Join(err, err1)
+		}
+		return 0, fmt.Errorf("send %s code to %s err:%w", purpose, email, err)
 	}
-	return false
+	return e.s.Options().Cooldown, nil
 }
 
-func (e *EmailWorker) SendEmailCode(ctx context.Context, email string) (string, error) {
-	em := email2.NewEmail()
-	em.From = e.cf.Sender
-	em.To = []string{email}
-	code := e.generateCode()
-	minutes := fmt.Sprintf("%d", e.cf.ExpirationSeconds/60)
-	// 设置邮件的HTML内容
-	em.HTML = []byte(`
-		<h1>Verification Code</h1>
-		<p>你的验证码是: <strong>` + code + `</strong>,该验证码将在` + minutes + `分钟后失效</p>
-	`)
-	em.Send("smtp.qq.com:587", smtp.PlainAuth("", e.cf.Sender, e.cf.Secret, "smtp.qq.com"))
-	err := e.c.SetEx(ctx, e.generateKey(email), code, e.cf.ExpirationSeconds)
+// SendNotice 发送欢迎、安全提醒等通知邮件，data 中的 Email 与 Time 未设置时自动填充
+func (e *EmailWorker) SendNotice(ctx context.Context, kind, email string, data map[string]string) error {
+	values := map[string]string{
+		"Email": email,
+		"Time":  time.Now().Format("2006-01-02 15:04:05 MST"),
+	}
+	for k, v := range data {
+		values[k] = v
+	}
+	msg, err := e.render(ctx, kind, email, values)
 	if err != nil {
-		return "", err
+		return err
 	}
-	return code, nil
+	if err = e.m.Send(ctx, msg); err != nil {
+		return fmt.Errorf("send %s mail to %s err:%w", kind, email, err)
+	}
+	return nil
 }
-func (e *EmailWorker) generateCode() string {
-	rand.Seed(time.Now().UnixNano())
-	// 四位大写英文字母与数字混合验证码
-	var result []byte
-	for i := 0; i < 4; i++ {
-		result = append(result, charset[rand.Intn(len(charset))])
-	}
-	return string(result)
+
+// PeekCode 读取尚未使用的验证码，只有配置开启 exposeCodes 时可用
+func (e *EmailWorker) PeekCode(ctx context.Context, purpose, email string) (string, error) {
+	code, err := e.s.Peek(ctx, purpose, email)
+	if errors.Is(err, verifycode.ErrorNotExposed) {
+		return "", errcode.VerifyCodeNotExposed
+	}
+	if errors.Is(err, verifycode.ErrorCodeInvalid) {
+		return "", errcode.VerifyCodeInvalid
+	}
+	return code, err
+}
+
+func idempotencyKey(purpose, email, code string) string {
+	sum := sha256.Sum256([]byte(purpose + "\x00" + email + "\x00" + code))
+	return "code:" + hex.EncodeToString(sum[:])
 }
-func (e *EmailWorker) generateKey(email string) string {
-	return fmt.Sprintf("email:%s", email)
+
+// render 按上下文中的语言渲染邮件
+func (e *EmailWorker) render(ctx context.Context, kind, email string, data map[string]string) (mail.Message, error) {
+	out, err := e.t.Render(kind, model.LocaleFromContext(ctx), data)
+	if err != nil {
+		return mail.Message{}, fmt.Errorf("render %s mail err:%w", kind, err)
+	}
+	return mail.Message{
+		To:      []string{email},
+		Subject: out.Subject,
+		HTML:    out.HTML,
+		Text:    out.Text,
+	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/TiktokCommence/userService/internal/conf"
 	cache3 "github.com/TiktokCommence/userService/internal/foundation/cache"
+	"github.com/TiktokCommence/userService/internal/model"
 	"testing"
 )
 
@@ -19,28 +20,38 @@ func initEmailWorker() *EmailWorker {
 		Wait:               true,
 	})
 	cache := cache3.NewCache(client)
-	ew := NewEmailWorker(cache, &conf.EmailConf{
+	ec := &conf.EmailConf{
 		Sender:            exampleEmail,
 		Secret:            "gmoyxtvrxqsfdhca",
 		ExpirationSeconds: 5 * 60,
-	})
+	}
+	mailer, err := NewMailer(ec)
+	if err != nil {
+		panic(err)
+	}
+	renderer, err := NewMailRenderer(ec)
+	if err != nil {
+		panic(err)
+	}
+	// 直接同步发送，不经过发件箱
+	ew := &EmailWorker{s: NewVerifyCodeStore(cache, &conf.VerifyCodeConf{}, ec), m: mailer, t: renderer}
 	return ew
 }
 
 func TestEmailWorker_SendEmailCode(t *testing.T) {
 	ew := initEmailWorker()
-	code, err := ew.SendEmailCode(context.Background(), exampleEmail)
+	cooldown, err := ew.SendCode(context.Background(), model.CodePurposeRegister, exampleEmail)
 	if err != nil {
 		t.Fatal(err)
 	}
-	t.Log(code)
+	t.Log(cooldown)
 }
 func TestEmailWorker_VerifyEmailCode(t *testing.T) {
 	ew := initEmailWorker()
-	ok := ew.VerifyEmailCode(context.Background(), exampleEmail, "4DH0")
-	if ok {
+	err := ew.VerifyCode(context.Background(), model.CodePurposeRegister, exampleEmail, "402913")
+	if err == nil {
 		t.Log("success\n")
 	} else {
-		t.Log("failed\n")
+		t.Logf("failed: %v\n", err)
 	}
 }
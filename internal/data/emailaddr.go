@@ -0,0 +1,27 @@
+package data
+
+import (
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	"github.com/TiktokCommence/userService/internal/foundation/emailaddr"
+)
+
+var _ biz.EmailCanonicalizer = (*EmailCanonicalizer)(nil)
+
+// EmailCanonicalizer 写入与查询邮箱前统一转换为规范形式，保证大小写等写法不同的邮箱对应同一个账号
+type EmailCanonicalizer struct {
+	c *emailaddr.Canonicalizer
+}
+
+func NewEmailCanonicalizer(c *conf.EmailConf) *EmailCanonicalizer {
+	return &EmailCanonicalizer{c: emailaddr.New(emailaddr.WithProviderRules(c.GetProviderRules()))}
+}
+
+func (e *EmailCanonicalizer) Canonicalize(email string) (string, error) {
+	canonical, err := e.c.Canonicalize(email)
+	if err != nil {
+		return "", errcode.EmailInvalid
+	}
+	return canonical, nil
+}
@@ -0,0 +1,47 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	cache2 "github.com/TiktokCommence/userService/internal/foundation/cache"
+	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"github.com/TiktokCommence/userService/internal/foundation/verifycode"
+	"time"
+)
+
+var _ biz.EmailChangeWorker = (*EmailChangeWorker)(nil)
+
+// EmailChangeWorker 记录用户申请修改的新邮箱，与发往新邮箱的验证码同时失效，
+// 保证验证码只能用于确认申请时填写的邮箱
+type EmailChangeWorker struct {
+	c   common.Cache
+	ttl time.Duration
+}
+
+func NewEmailChangeWorker(c common.Cache, s *verifycode.Store) *EmailChangeWorker {
+	return &EmailChangeWorker{c: c, ttl: s.Options().TTL}
+}
+
+func (w *EmailChangeWorker) SavePending(ctx context.Context, userID uint64, newEmail string) error {
+	return w.c.SetEx(ctx, w.generateKey(userID), newEmail, int64(w.ttl/time.Second))
+}
+
+// Pending 返回待确认的新邮箱，没有时返回 errcode.EmailChangeNotRequested
+func (w *EmailChangeWorker) Pending(ctx context.Context, userID uint64) (string, error) {
+	email, err := w.c.Get(ctx, w.generateKey(userID))
+	if errors.Is(err, cache2.ErrorCacheMiss) {
+		return "", errcode.EmailChangeNotRequested
+	}
+	return email, err
+}
+
+func (w *EmailChangeWorker) ClearPending(ctx context.Context, userID uint64) error {
+	return w.c.Del(ctx, w.generateKey(userID))
+}
+
+func (w *EmailChangeWorker) generateKey(userID uint64) string {
+	return fmt.Sprintf("email_change:%d", userID)
+}
@@ -0,0 +1,295 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/conf"
+	cache2 "github.com/TiktokCommence/userService/internal/foundation/cache"
+	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"github.com/TiktokCommence/userService/internal/foundation/snowflake"
+	"github.com/TiktokCommence/userService/internal/foundation/token"
+	"github.com/go-kratos/kratos/v2/log"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var _ biz.GenerateID = (*SnowflakeIDGenerator)(nil)
+
+const (
+	IDGeneratorCounter   = "counter"
+	IDGeneratorSnowflake = "snowflake"
+	IDGeneratorSegment   = "segment"
+
+	DefaultWorkerLeaseTTL = 30 * time.Second
+)
+
+var (
+	ErrorNoWorkerID         = errors.New("all snowflake worker ids are leased")
+	ErrorWorkerLeaseExpired = errors.New("snowflake worker id lease expired")
+)
+
+// NewIDGenerator 按配置选择用户 ID 生成方式，snowflake 需要先从 redis 租用 worker ID，
+// 返回的 cleanup 会停止续约并释放 worker ID；segment 从数据库预留号段，不依赖 redis
+func NewIDGenerator(c *conf.IDConf, db common.DB, cache common.Cache, counter *RedisWorkerImplement, logger log.Logger) (biz.GenerateID, func(), error) {
+	switch c.GetGenerator() {
+	case "", IDGeneratorCounter:
+		return counter, func() {}, nil
+	case IDGeneratorSnowflake:
+		g, err := NewSnowflakeIDGenerator(c, cache, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		return g, g.Close, nil
+	case IDGeneratorSegment:
+		g, err := NewSegmentIDGenerator(c, db, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		return g, func() {}, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported id generator %q", c.GetGenerator())
+	}
+}
+
+// SnowflakeIDGenerator 按时间递增的 64 位用户 ID，worker ID 通过 redis 租约保证同一时刻只被一个实例使用。
+// 续约时记录租约到期前可能生成的最后一个 ID 的时间，正常退出时记录实际的最后一个 ID 的时间，
+// 下一次租用同一个 worker ID 时先等待时钟越过该时间再发号，避免重启后时钟回拨产生重复 ID
+type SnowflakeIDGenerator struct {
+	c     common.Cache
+	g     *snowflake.Generator
+	h     *log.Helper
+	ttl   time.Duration
+	key   string
+	token string
+	// 可以发号的截止时间（unix 纳秒），为租约请求发出的时刻加上租约时长再减去余量，
+	// 发号时直接检查，不依赖续约协程是否按时运行
+	deadline atomic.Int64
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+func NewSnowflakeIDGenerator(c *conf.IDConf, cache common.Cache, logger log.Logger) (*SnowflakeIDGenerator, error) {
+	ttl := c.GetLeaseTTL().AsDuration()
+	// 租约以秒为单位
+	if ttl < time.Second {
+		ttl = DefaultWorkerLeaseTTL
+	}
+	leaseToken, err := token.RandomString(16)
+	if err != nil {
+		return nil, err
+	}
+	s := &SnowflakeIDGenerator{
+		c:     cache,
+		h:     log.NewHelper(logger),
+		ttl:   ttl,
+		token: leaseToken,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	// 启动时最多等待一个租约时长，见 waitLastTime
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second+ttl)
+	defer cancel()
+	workerID, err := s.lease(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := []snowflake.Option{snowflake.WithMaxBackward(c.GetMaxClockBackward().AsDuration())}
+	if c.GetEpoch() != nil {
+		opts = append(opts, snowflake.WithEpoch(c.GetEpoch().AsTime()))
+	}
+	last, err := s.lastTime(ctx, workerID)
+	if err == nil {
+		err = s.waitLastTime(ctx, workerID, last)
+	}
+	if err != nil {
+		s.release(ctx)
+		return nil, err
+	}
+	if !last.IsZero() {
+		opts = append(opts, snowflake.WithLast(last))
+	}
+	s.g, err = snowflake.NewGenerator(workerID, opts...)
+	if err != nil {
+		s.release(ctx)
+		return nil, err
+	}
+	s.saveLeaseBound(ctx)
+	s.h.Infof("leased snowflake worker id %d", workerID)
+	go s.renew()
+	return s, nil
+}
+
+func (s *SnowflakeIDGenerator) GenerateUserID(ctx context.Context) (uint64, error) {
+	if time.Now().UnixNano() >= s.deadline.Load() {
+		return 0, ErrorWorkerLeaseExpired
+	}
+	return s.g.Next()
+}
+
+// Decode 解析用户 ID 的生成时间与 worker ID，用于排查问题
+func (s *SnowflakeIDGenerator) Decode(id uint64) snowflake.Parts {
+	return s.g.Decode(id)
+}
+
+// Close 停止续约，记录最后一个 ID 的时间并释放 worker ID
+func (s *SnowflakeIDGenerator) Close() {
+	s.once.Do(func() {
+		close(s.stop)
+		<-s.done
+		s.g.Stop()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		// 覆盖续约时记录的上界，下一个实例不需要等待租约时长
+		last := s.g.Last()
+		if last.IsZero() {
+			last = time.Now()
+		}
+		s.saveLastTime(ctx, last)
+		s.release(ctx)
+	})
+}
+
+// lease 从随机位置开始依次尝试租用 worker ID，减少多个实例同时启动时的冲突
+func (s *SnowflakeIDGenerator) lease(ctx context.Context) (int64, error) {
+	start := rand.Int63n(snowflake.MaxWorkerID + 1)
+	for i := int64(0); i <= snowflake.MaxWorkerID; i++ {
+		workerID := (start + i) % (snowflake.MaxWorkerID + 1)
+		key := s.generateLeaseKey(workerID)
+		sent := time.Now()
+		ok, err := s.c.SetNX(ctx, key, s.token, int64(s.ttl/time.Second))
+		if err != nil {
+			return 0, fmt.Errorf("lease snowflake worker id err:%w", err)
+		}
+		if ok {
+			s.key = key
+			s.extend(sent)
+			return workerID, nil
+		}
+	}
+	return 0, ErrorNoWorkerID
+}
+
+// renew 定期续约，租约被其他实例占用或超过租约时长仍未续约成功时停止发号
+func (s *SnowflakeIDGenerator) renew() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.renewInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), s.renewInterval())
+		sent := time.Now()
+		ok, err := s.c.ExpireIfEqual(ctx, s.key, s.token, int64(s.ttl/time.Second))
+		if err == nil && ok {
+			s.extend(sent)
+			s.saveLeaseBound(ctx)
+		}
+		cancel()
+		if err == nil && !ok {
+			s.h.Errorf("snowflake worker id lease %s is lost, stop generating ids", s.key)
+			s.g.Stop()
+			return
+		}
+		if err != nil {
+			s.h.Warnf("renew snowflake worker id lease %s error:%v", s.key, err)
+			if time.Now().UnixNano() >= s.deadline.Load() {
+				s.h.Errorf("snowflake worker id lease %s expired, stop generating ids", s.key)
+				s.g.Stop()
+				return
+			}
+		}
+	}
+}
+
+// extend 租约在请求发出之后才开始计时，以发出的时刻计算截止时间，并留出余量应对实例与 redis 之间的时钟速率差异
+func (s *SnowflakeIDGenerator) extend(sent time.Time) {
+	s.deadline.Store(sent.Add(s.ttl - s.ttl/10).UnixNano())
+}
+
+func (s *SnowflakeIDGenerator) release(ctx context.Context) {
+	if _, err := s.c.DelIfEqual(ctx, s.key, s.token); err != nil {
+		s.h.Warnf("release snowflake worker id lease %s error:%v", s.key, err)
+	}
+}
+
+func (s *SnowflakeIDGenerator) lastTime(ctx context.Context, workerID int64) (time.Time, error) {
+	val, err := s.c.Get(ctx, s.generateLastKey(workerID))
+	if errors.Is(err, cache2.ErrorCacheMiss) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	ms, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse last time of snowflake worker %d err:%w", workerID, err)
+	}
+	return time.UnixMilli(ms), nil
+}
+
+// waitLastTime 上一个实例异常退出时记录的是租约到期时刻，可能晚于当前时间，等待时钟越过该时刻再发号。
+// 需要等待超过一个租约时长说明时钟发生了回拨，返回错误；等待之后重新续约，避免租约在等待期间到期
+func (s *SnowflakeIDGenerator) waitLastTime(ctx context.Context, workerID int64, last time.Time) error {
+	wait := time.Until(last)
+	if wait <= 0 {
+		return nil
+	}
+	if wait > s.ttl {
+		return fmt.Errorf("last time of snowflake worker %d is %s ahead:%w", workerID, wait, snowflake.ErrorClockBackwards)
+	}
+	s.h.Infof("wait %s for the last time of snowflake worker %d", wait, workerID)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+	sent := time.Now()
+	ok, err := s.c.ExpireIfEqual(ctx, s.key, s.token, int64(s.ttl/time.Second))
+	if err != nil {
+		return fmt.Errorf("renew snowflake worker id lease err:%w", err)
+	}
+	if !ok {
+		return ErrorWorkerLeaseExpired
+	}
+	s.extend(sent)
+	return nil
+}
+
+// saveLeaseBound 租约到期前本实例都可能继续发号，记录租约到期时刻作为最后一个 ID 时间的上界
+func (s *SnowflakeIDGenerator) saveLeaseBound(ctx context.Context) {
+	bound := time.Now()
+	if last := s.g.Last(); last.After(bound) {
+		bound = last
+	}
+	s.saveLastTime(ctx, bound.Add(s.ttl))
+}
+
+func (s *SnowflakeIDGenerator) saveLastTime(ctx context.Context, last time.Time) {
+	if err := s.c.Set(ctx, s.generateLastKey(s.g.WorkerID()), strconv.FormatInt(last.UnixMilli(), 10)); err != nil {
+		s.h.Warnf("save last time of snowflake worker %d error:%v", s.g.WorkerID(), err)
+	}
+}
+
+func (s *SnowflakeIDGenerator) renewInterval() time.Duration {
+	return s.ttl / 3
+}
+
+func (s *SnowflakeIDGenerator) generateLeaseKey(workerID int64) string {
+	return fmt.Sprintf("id_worker:%d", workerID)
+}
+
+func (s *SnowflakeIDGenerator) generateLastKey(workerID int64) string {
+	return fmt.Sprintf("id_worker_last:%d", workerID)
+}
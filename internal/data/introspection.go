@@ -0,0 +1,32 @@
+package data
+
+import (
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/foundation/localcache"
+	"github.com/TiktokCommence/userService/internal/model"
+	"time"
+)
+
+var _ biz.IntrospectionCache = (*IntrospectionCache)(nil)
+
+type IntrospectionCache struct {
+	c *localcache.Cache[string, model.TokenIntrospection]
+}
+
+func NewIntrospectionCache(c *conf.TokenConf) *IntrospectionCache {
+	return &IntrospectionCache{
+		c: localcache.New[string, model.TokenIntrospection](
+			int(c.GetIntrospectionCacheSize()),
+			c.GetIntrospectionCacheTTL().AsDuration(),
+		),
+	}
+}
+
+func (i *IntrospectionCache) Get(accessToken string) (model.TokenIntrospection, bool) {
+	return i.c.Get(accessToken)
+}
+
+func (i *IntrospectionCache) Set(accessToken string, result model.TokenIntrospection, ttl time.Duration) {
+	i.c.SetWithTTL(accessToken, result, ttl)
+}
@@ -0,0 +1,104 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/conf"
+	cache2 "github.com/TiktokCommence/userService/internal/foundation/cache"
+	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"github.com/TiktokCommence/userService/internal/foundation/token"
+	"github.com/go-kratos/kratos/v2/log"
+	"time"
+)
+
+var _ biz.LoadLock = (*LoadLock)(nil)
+
+const (
+	DefaultLoadLockTTL = 3 * time.Second
+	DefaultLoadWait    = 500 * time.Millisecond
+
+	loadWaitInterval = 20 * time.Millisecond
+)
+
+var ErrorLoadWaitTimeout = errors.New("wait for user load lock timeout")
+
+// LoadLock 用户缓存未命中时的回源锁，关闭时每个实例各自查询数据库
+type LoadLock struct {
+	c       common.Cache
+	h       *log.Helper
+	enabled bool
+	ttl     time.Duration
+	wait    time.Duration
+}
+
+func NewLoadLock(c *conf.CacheConf, cache common.Cache, logger log.Logger) *LoadLock {
+	l := &LoadLock{
+		c:       cache,
+		h:       log.NewHelper(logger),
+		enabled: c.GetLoadLock(),
+		ttl:     c.GetLoadLockTTL().AsDuration(),
+		wait:    c.GetLoadWait().AsDuration(),
+	}
+	// 锁以秒为单位过期
+	if l.ttl < time.Second {
+		l.ttl = DefaultLoadLockTTL
+	}
+	if l.wait <= 0 {
+		l.wait = DefaultLoadWait
+	}
+	return l
+}
+
+// TryLock 以随机值作为锁的持有者标识，释放时只删除自己持有的锁
+func (l *LoadLock) TryLock(ctx context.Context, id uint64) (func(), bool, error) {
+	if !l.enabled {
+		return func() {}, true, nil
+	}
+	owner, err := token.RandomString(16)
+	if err != nil {
+		return nil, false, err
+	}
+	key := l.generateKey(id)
+	ok, err := l.c.SetNX(ctx, key, owner, int64(l.ttl/time.Second))
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	unlock := func() {
+		// 调用方的 ctx 可能已经取消，释放锁使用单独的超时
+		tctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if _, err := l.c.DelIfEqual(tctx, key, owner); err != nil {
+			l.h.Warnf("release load lock %s error:%v", key, err)
+		}
+	}
+	return unlock, true, nil
+}
+
+// Wait 轮询直到锁被释放或过期，超过等待时长返回 ErrorLoadWaitTimeout
+func (l *LoadLock) Wait(ctx context.Context, id uint64) error {
+	key := l.generateKey(id)
+	ctx, cancel := context.WithTimeout(ctx, l.wait)
+	defer cancel()
+	ticker := time.NewTicker(loadWaitInterval)
+	defer ticker.Stop()
+	for {
+		_, err := l.c.Get(ctx, key)
+		if errors.Is(err, cache2.ErrorCacheMiss) {
+			return nil
+		}
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s", ErrorLoadWaitTimeout, key)
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *LoadLock) generateKey(id uint64) string {
+	return fmt.Sprintf("user_load_lock:%d", id)
+}
@@ -0,0 +1,187 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/conf"
+	cache2 "github.com/TiktokCommence/userService/internal/foundation/cache"
+	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"strconv"
+	"time"
+)
+
+var _ biz.LoginLimiter = (*LoginLimiter)(nil)
+
+const (
+	DefaultAccountThreshold     = 5
+	DefaultIPThreshold          = 50
+	DefaultLockoutWindow        = 15 * time.Minute
+	DefaultBaseLockDuration     = time.Minute
+	DefaultMaxLockDuration      = time.Hour
+	DefaultEscalationResetAfter = 24 * time.Hour
+)
+
+const (
+	lockScopeAccount = "account"
+	lockScopeIP      = "ip"
+)
+
+// LoginLimiter 统计账号与 IP 维度的登录失败次数，失败次数超过阈值后锁定，连续被锁定时锁定时长逐级翻倍
+type LoginLimiter struct {
+	c                    common.Cache
+	accountThreshold     int64
+	ipThreshold          int64
+	window               time.Duration
+	baseLockDuration     time.Duration
+	maxLockDuration      time.Duration
+	escalationResetAfter time.Duration
+}
+
+func NewLoginLimiter(c common.Cache, lc *conf.LockoutConf) *LoginLimiter {
+	l := &LoginLimiter{
+		c:                    c,
+		accountThreshold:     lc.GetAccountThreshold(),
+		ipThreshold:          lc.GetIpThreshold(),
+		window:               lc.GetWindow().AsDuration(),
+		baseLockDuration:     lc.GetBaseLockDuration().AsDuration(),
+		maxLockDuration:      lc.GetMaxLockDuration().AsDuration(),
+		escalationResetAfter: lc.GetEscalationResetAfter().AsDuration(),
+	}
+	if l.accountThreshold <= 0 {
+		l.accountThreshold = DefaultAccountThreshold
+	}
+	if l.ipThreshold <= 0 {
+		l.ipThreshold = DefaultIPThreshold
+	}
+	if l.window <= 0 {
+		l.window = DefaultLockoutWindow
+	}
+	if l.baseLockDuration <= 0 {
+		l.baseLockDuration = DefaultBaseLockDuration
+	}
+	if l.maxLockDuration < l.baseLockDuration {
+		l.maxLockDuration = max(DefaultMaxLockDuration, l.baseLockDuration)
+	}
+	if l.escalationResetAfter <= 0 {
+		l.escalationResetAfter = DefaultEscalationResetAfter
+	}
+	return l
+}
+
+// Locked 返回账号或 IP 剩余的锁定时长，二者都未被锁定时返回 0
+func (l *LoginLimiter) Locked(ctx context.Context, account, ip string) (time.Duration, error) {
+	retry, err := l.lockRemaining(ctx, lockScopeAccount, account)
+	if err != nil || retry > 0 {
+		return retry, err
+	}
+	if ip == "" {
+		return 0, nil
+	}
+	return l.lockRemaining(ctx, lockScopeIP, ip)
+}
+
+// RecordFailure 记录一次登录失败，本次失败触发锁定时返回锁定时长
+func (l *LoginLimiter) RecordFailure(ctx context.Context, account, ip string) (time.Duration, error) {
+	retry, err := l.recordFailure(ctx, lockScopeAccount, account, l.accountThreshold)
+	if err != nil {
+		return 0, err
+	}
+	if ip != "" {
+		ipRetry, err := l.recordFailure(ctx, lockScopeIP, ip, l.ipThreshold)
+		if err != nil {
+			return 0, err
+		}
+		retry = max(retry, ipRetry)
+	}
+	return retry, nil
+}
+
+// Reset 登录成功后清空账号的失败次数，锁定等级保留到 escalationResetAfter 之后自然过期
+func (l *LoginLimiter) Reset(ctx context.Context, account string) error {
+	return l.c.Del(ctx, l.generateFailKey(lockScopeAccount, account))
+}
+
+// Unlock 由管理员解除锁定，同时清空失败次数与锁定等级
+func (l *LoginLimiter) Unlock(ctx context.Context, account, ip string) error {
+	scopes := map[string]string{lockScopeAccount: account, lockScopeIP: ip}
+	for scope, subject := range scopes {
+		if subject == "" {
+			continue
+		}
+		for _, key := range []string{
+			l.generateLockKey(scope, subject),
+			l.generateFailKey(scope, subject),
+			l.generateLevelKey(scope, subject),
+		} {
+			if err := l.c.Del(ctx, key); err != nil {
+				return fmt.Errorf("unlock %s %s err:%w", scope, subject, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (l *LoginLimiter) lockRemaining(ctx context.Context, scope, subject string) (time.Duration, error) {
+	val, err := l.c.Get(ctx, l.generateLockKey(scope, subject))
+	if errors.Is(err, cache2.ErrorCacheMiss) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get lock of %s %s err:%w", scope, subject, err)
+	}
+	// 锁定 key 的值为解锁时间的 unix 时间戳
+	until, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse lock of %s %s err:%w", scope, subject, err)
+	}
+	retry := time.Until(time.Unix(until, 0))
+	if retry <= 0 {
+		return 0, nil
+	}
+	return retry, nil
+}
+
+// recordFailure 计数、首次计数的过期时间与锁定等级的升级在一个脚本中完成，并发失败时每次达到阈值只会锁定一次
+func (l *LoginLimiter) recordFailure(ctx context.Context, scope, subject string, threshold int64) (time.Duration, error) {
+	level, err := l.c.IncrEscalate(ctx, l.generateFailKey(scope, subject), l.generateLevelKey(scope, subject),
+		threshold, seconds(l.window), seconds(l.escalationResetAfter))
+	if err != nil {
+		return 0, fmt.Errorf("count login failure of %s %s err:%w", scope, subject, err)
+	}
+	if level == 0 {
+		return 0, nil
+	}
+	duration := l.lockDuration(level)
+	until := time.Now().Add(duration).Unix()
+	if err = l.c.SetEx(ctx, l.generateLockKey(scope, subject), strconv.FormatInt(until, 10), seconds(duration)); err != nil {
+		return 0, fmt.Errorf("lock %s %s err:%w", scope, subject, err)
+	}
+	return duration, nil
+}
+
+// lockDuration 第 level 次锁定的时长为 baseLockDuration * 2^(level-1)，不超过 maxLockDuration
+func (l *LoginLimiter) lockDuration(level int64) time.Duration {
+	duration := l.baseLockDuration
+	for i := int64(1); i < level && duration < l.maxLockDuration; i++ {
+		duration *= 2
+	}
+	return min(duration, l.maxLockDuration)
+}
+
+func (l *LoginLimiter) generateFailKey(scope, subject string) string {
+	return fmt.Sprintf("login_fail:%s:%s", scope, subject)
+}
+
+func (l *LoginLimiter) generateLockKey(scope, subject string) string {
+	return fmt.Sprintf("login_lock:%s:%s", scope, subject)
+}
+
+func (l *LoginLimiter) generateLevelKey(scope, subject string) string {
+	return fmt.Sprintf("login_lock_level:%s:%s", scope, subject)
+}
+
+func seconds(d time.Duration) int64 {
+	return max(int64(d/time.Second), 1)
+}
@@ -0,0 +1,114 @@
+package data
+
+import (
+	"context"
+	"github.com/TiktokCommence/userService/internal/conf"
+	cache2 "github.com/TiktokCommence/userService/internal/foundation/cache"
+	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// lockoutCache 只实现登录锁定用到的操作，不处理过期
+type lockoutCache struct {
+	common.Cache
+	m map[string]string
+}
+
+func (c *lockoutCache) Get(ctx context.Context, key string) (string, error) {
+	v, ok := c.m[key]
+	if !ok {
+		return "", cache2.ErrorCacheMiss
+	}
+	return v, nil
+}
+
+func (c *lockoutCache) SetEx(ctx context.Context, key, value string, expireSeconds int64) error {
+	c.m[key] = value
+	return nil
+}
+
+func (c *lockoutCache) Del(ctx context.Context, key string) error {
+	delete(c.m, key)
+	return nil
+}
+
+func (c *lockoutCache) IncrEscalate(ctx context.Context, countKey, levelKey string, threshold, countExpireSeconds, levelExpireSeconds int64) (int64, error) {
+	count, _ := strconv.ParseInt(c.m[countKey], 10, 64)
+	if count++; count < threshold {
+		c.m[countKey] = strconv.FormatInt(count, 10)
+		return 0, nil
+	}
+	delete(c.m, countKey)
+	level, _ := strconv.ParseInt(c.m[levelKey], 10, 64)
+	level++
+	c.m[levelKey] = strconv.FormatInt(level, 10)
+	return level, nil
+}
+
+func TestLoginLimiter_Escalation(t *testing.T) {
+	ctx := context.Background()
+	c := &lockoutCache{m: make(map[string]string)}
+	l := NewLoginLimiter(c, &conf.LockoutConf{
+		AccountThreshold: 2,
+		IpThreshold:      100,
+		BaseLockDuration: durationpb.New(time.Minute),
+		MaxLockDuration:  durationpb.New(5 * time.Minute),
+	})
+	// 模拟锁定到期，锁定等级保留
+	expire := func() { delete(c.m, l.generateLockKey(lockScopeAccount, "a")) }
+
+	cases := []struct {
+		name string
+		// 本次失败触发的锁定时长，0 表示未触发
+		expect time.Duration
+		after  func()
+	}{
+		{name: "first", expect: 0},
+		{name: "lock 1", expect: time.Minute, after: expire},
+		{name: "first again", expect: 0},
+		{name: "lock 2", expect: 2 * time.Minute, after: expire},
+		{name: "first again", expect: 0},
+		{name: "lock 3", expect: 4 * time.Minute, after: expire},
+		{name: "first again", expect: 0},
+		// 不超过 maxLockDuration
+		{name: "lock 4", expect: 5 * time.Minute},
+	}
+	for _, cs := range cases {
+		retry, err := l.RecordFailure(ctx, "a", "1.1.1.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if retry != cs.expect {
+			t.Fatalf("%s: expected lock for %s, got %s", cs.name, cs.expect, retry)
+		}
+		if cs.expect > 0 {
+			locked, err := l.Locked(ctx, "a", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if locked <= 0 || locked > cs.expect {
+				t.Errorf("%s: expected remaining lock within %s, got %s", cs.name, cs.expect, locked)
+			}
+		}
+		if cs.after != nil {
+			cs.after()
+		}
+	}
+
+	// 解除锁定同时清空锁定等级
+	if err := l.Unlock(ctx, "a", "1.1.1.1"); err != nil {
+		t.Fatal(err)
+	}
+	if retry, _ := l.Locked(ctx, "a", "1.1.1.1"); retry != 0 {
+		t.Errorf("expected unlocked, got %s", retry)
+	}
+	if _, err := l.RecordFailure(ctx, "a", ""); err != nil {
+		t.Fatal(err)
+	}
+	if retry, _ := l.RecordFailure(ctx, "a", ""); retry != time.Minute {
+		t.Errorf("expected escalation to restart after unlock, got %s", retry)
+	}
+}
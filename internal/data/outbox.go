@@ -0,0 +1,251 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/foundation/DB"
+	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"github.com/TiktokCommence/userService/internal/foundation/mail"
+	"github.com/TiktokCommence/userService/internal/foundation/token"
+	"github.com/TiktokCommence/userService/internal/model"
+	"github.com/go-kratos/kratos/v2/log"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+var _ mail.Mailer = (*Outbox)(nil)
+
+const (
+	DefaultOutboxBatchSize   = 20
+	DefaultOutboxMaxAttempts = 8
+	DefaultOutboxBaseBackoff = 5 * time.Second
+	DefaultOutboxMaxBackoff  = 30 * time.Minute
+	DefaultOutboxLease       = time.Minute
+	DefaultOutboxRetention   = 7 * 24 * time.Hour
+
+	// outboxPurgeInterval 两次清理已发送邮件之间的最短间隔
+	outboxPurgeInterval = time.Hour
+)
+
+// Outbox 邮件先写入 MySQL 发件箱，由后台任务通过 DispatchOnce 异步发送，
+// 发送失败按指数退避重试，重试次数用尽或超过过期时间后转为 dead。同一封邮件至少发送一次。
+// 发送成功或转为 dead 后清空正文，已发送的邮件超过保留时长后删除
+type Outbox struct {
+	d           common.DB
+	m           mail.Mailer
+	batchSize   int
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	lease       time.Duration
+	retention   time.Duration
+	h           *log.Helper
+	// 只由后台任务的单个循环访问
+	lastPurge time.Time
+}
+
+func NewOutbox(d common.DB, m mail.Mailer, c *conf.OutboxConf, logger log.Logger) *Outbox {
+	o := &Outbox{
+		d:           d,
+		m:           m,
+		batchSize:   int(c.GetBatchSize()),
+		maxAttempts: int(c.GetMaxAttempts()),
+		baseBackoff: c.GetBaseBackoff().AsDuration(),
+		maxBackoff:  c.GetMaxBackoff().AsDuration(),
+		lease:       c.GetLease().AsDuration(),
+		retention:   c.GetRetention().AsDuration(),
+		h:           log.NewHelper(logger),
+	}
+	if o.batchSize <= 0 {
+		o.batchSize = DefaultOutboxBatchSize
+	}
+	if o.maxAttempts <= 0 {
+		o.maxAttempts = DefaultOutboxMaxAttempts
+	}
+	if o.baseBackoff <= 0 {
+		o.baseBackoff = DefaultOutboxBaseBackoff
+	}
+	if o.maxBackoff < o.baseBackoff {
+		o.maxBackoff = max(DefaultOutboxMaxBackoff, o.baseBackoff)
+	}
+	if o.lease <= 0 {
+		o.lease = DefaultOutboxLease
+	}
+	if o.retention <= 0 {
+		o.retention = DefaultOutboxRetention
+	}
+	return o
+}
+
+// Send 把邮件写入发件箱，幂等键已经存在时视为已入队
+func (o *Outbox) Send(ctx context.Context, msg mail.Message) error {
+	if len(msg.To) == 0 {
+		return mail.ErrorNoRecipient
+	}
+	record := model.OutboxMessage{
+		IdempotencyKey: msg.IdempotencyKey,
+		Recipient:      strings.Join(msg.To, ","),
+		Subject:        msg.Subject,
+		HTML:           msg.HTML,
+		Text:           msg.Text,
+		Status:         model.OutboxStatusPending,
+		NextAttemptAt:  time.Now(),
+	}
+	if !msg.ExpiresAt.IsZero() {
+		record.ExpiresAt = &msg.ExpiresAt
+	}
+	if record.IdempotencyKey == "" {
+		key, err := randomKey()
+		if err != nil {
+			return err
+		}
+		record.IdempotencyKey = key
+	}
+	err := o.d.Put(ctx, &record)
+	if errors.Is(err, DB.ErrorDBDuplicateEntry) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("enqueue mail to %s err:%w", record.Recipient, err)
+	}
+	return nil
+}
+
+// DispatchOnce 领取一批到期的邮件并发送，返回本次领取到的邮件数量
+func (o *Outbox) DispatchOnce(ctx context.Context) (int, error) {
+	o.purge(ctx)
+	var due []model.OutboxMessage
+	err := o.d.Find(ctx, &model.OutboxMessage{}, &due, "status = ? AND next_attempt_at <= ?",
+		[]interface{}{model.OutboxStatusPending, time.Now()}, "next_attempt_at", o.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("find due mails err:%w", err)
+	}
+	claimed := 0
+	for _, msg := range due {
+		ok, err := o.claim(ctx, msg)
+		if err != nil {
+			return claimed, err
+		}
+		if !ok {
+			continue
+		}
+		claimed++
+		o.deliver(ctx, msg)
+	}
+	return claimed, nil
+}
+
+// claim 把邮件的下一次尝试时间推迟到租约到期，只有推迟成功的实例负责发送
+func (o *Outbox) claim(ctx context.Context, msg model.OutboxMessage) (bool, error) {
+	now := time.Now()
+	n, err := o.d.UpdateColumns(ctx, &model.OutboxMessage{}, "id = ? AND status = ? AND next_attempt_at <= ?",
+		[]interface{}{msg.ID, model.OutboxStatusPending, now},
+		map[string]interface{}{"next_attempt_at": now.Add(o.lease)})
+	if err != nil {
+		return false, fmt.Errorf("claim mail %d err:%w", msg.ID, err)
+	}
+	return n > 0, nil
+}
+
+func (o *Outbox) deliver(ctx context.Context, msg model.OutboxMessage) {
+	if expiresBefore(msg, time.Now()) {
+		o.h.Warnf("mail %d to %s expired before it was sent", msg.ID, msg.Recipient)
+		o.update(ctx, msg.ID, map[string]interface{}{
+			"status":     model.OutboxStatusDead,
+			"last_error": "expired before sent",
+			"html":       "",
+			"text":       "",
+		})
+		return
+	}
+	attempts := msg.Attempts + 1
+	values := map[string]interface{}{"attempts": attempts}
+	err := o.m.Send(ctx, mail.Message{
+		To:             strings.Split(msg.Recipient, ","),
+		Subject:        msg.Subject,
+		HTML:           msg.HTML,
+		Text:           msg.Text,
+		IdempotencyKey: msg.IdempotencyKey,
+	})
+	next := time.Now().Add(o.backoff(attempts))
+	switch {
+	case err == nil:
+		values["status"] = model.OutboxStatusSent
+		values["sent_at"] = time.Now()
+		values["last_error"] = ""
+		values["html"] = ""
+		values["text"] = ""
+	case attempts >= o.maxAttempts || expiresBefore(msg, next):
+		// 重试次数用尽，或下一次重试时邮件已经过期
+		o.h.Errorf("mail %d to %s is dead after %d attempts, last error:%v", msg.ID, msg.Recipient, attempts, err)
+		values["status"] = model.OutboxStatusDead
+		values["last_error"] = truncate(err.Error(), 500)
+		values["html"] = ""
+		values["text"] = ""
+	default:
+		o.h.Warnf("send mail %d to %s failed at attempt %d:%v", msg.ID, msg.Recipient, attempts, err)
+		values["next_attempt_at"] = next
+		values["last_error"] = truncate(err.Error(), 500)
+	}
+	o.update(ctx, msg.ID, values)
+}
+
+func (o *Outbox) update(ctx context.Context, id uint64, values map[string]interface{}) {
+	if _, err := o.d.UpdateColumns(ctx, &model.OutboxMessage{}, "id = ?", []interface{}{id}, values); err != nil {
+		// 状态没有更新成功时，租约到期后邮件会被再次处理
+		o.h.Errorf("update mail %d status error:%v", id, err)
+	}
+}
+
+// purge 每隔 outboxPurgeInterval 删除超过保留时长的已发送邮件，dead 邮件留给人工处理
+func (o *Outbox) purge(ctx context.Context) {
+	now := time.Now()
+	if now.Sub(o.lastPurge) < outboxPurgeInterval {
+		return
+	}
+	o.lastPurge = now
+	n, err := o.d.DeleteWhere(ctx, &model.OutboxMessage{}, "status = ? AND sent_at < ?",
+		[]interface{}{model.OutboxStatusSent, now.Add(-o.retention)})
+	if err != nil {
+		o.h.Warnf("purge sent mails error:%v", err)
+		return
+	}
+	if n > 0 {
+		o.h.Infof("purged %d sent mails", n)
+	}
+}
+
+// expiresBefore 邮件设置了过期时间且不晚于 t
+func expiresBefore(msg model.OutboxMessage, t time.Time) bool {
+	return msg.ExpiresAt != nil && !t.Before(*msg.ExpiresAt)
+}
+
+// backoff 第 attempts 次失败后的等待时长为 baseBackoff * 2^(attempts-1)，不超过 maxBackoff，并加入 20% 以内的随机抖动
+func (o *Outbox) backoff(attempts int) time.Duration {
+	d := o.baseBackoff
+	for i := 1; i < attempts && d < o.maxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, o.maxBackoff)
+	return d + time.Duration(rand.Int64N(int64(d)/5+1))
+}
+
+// truncate 按字符截断，避免截断多字节字符
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
+
+func randomKey() (string, error) {
+	b, err := token.RandomString(24)
+	if err != nil {
+		return "", err
+	}
+	return "random:" + b, nil
+}
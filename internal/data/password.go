@@ -0,0 +1,52 @@
+package data
+
+import (
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/foundation/password"
+	"github.com/TiktokCommence/userService/internal/model"
+)
+
+var _ biz.PasswordPolicy = (*PasswordPolicy)(nil)
+
+type PasswordPolicy struct {
+	p *password.Policy
+}
+
+func NewPasswordPolicy(c *conf.PasswordConf) (*PasswordPolicy, error) {
+	pc := c.GetPolicy()
+	var maxBytes int
+	if a := c.GetAlgorithm(); a == "" || a == password.AlgorithmBcrypt {
+		maxBytes = password.BcryptMaxBytes
+	}
+	p, err := password.NewPolicy(password.PolicyConfig{
+		MinLength:           int(pc.GetMinLength()),
+		MaxLength:           int(pc.GetMaxLength()),
+		RequireUpper:        pc.GetRequireUpper(),
+		RequireLower:        pc.GetRequireLower(),
+		RequireDigit:        pc.GetRequireDigit(),
+		RequireSymbol:       pc.GetRequireSymbol(),
+		ForbiddenSequences:  pc.GetForbiddenSequences(),
+		MaxRepeat:           int(pc.GetMaxRepeat()),
+		MaxSequence:         int(pc.GetMaxSequence()),
+		CommonPasswordsFile: pc.GetCommonPasswordsFile(),
+		AllowEmailSimilar:   pc.GetAllowEmailSimilar(),
+		MaxBytes:            maxBytes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &PasswordPolicy{p: p}, nil
+}
+
+func (p *PasswordPolicy) Check(pw, email string) []model.PasswordViolation {
+	violations := p.p.Check(pw, email)
+	if len(violations) == 0 {
+		return nil
+	}
+	res := make([]model.PasswordViolation, 0, len(violations))
+	for _, v := range violations {
+		res = append(res, model.PasswordViolation{Code: v.Code, Message: v.Message})
+	}
+	return res
+}
@@ -10,28 +10,23 @@ import (
 	"github.com/TiktokCommence/userService/internal/model"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/gomodule/redigo/redis"
-	"sync"
 )
 
 var _ biz.RedisWorker = (*RedisWorkerImplement)(nil)
 
 const NullData = "Err_Syntax_Null_Data"
 
-const StartValue int64 = 0
-
 const IDKey = "id"
 
 type RedisWorkerImplement struct {
-	c    common.Cache
-	opt  *cache2.Options
-	once sync.Once
-	h    *log.Helper
+	c   common.Cache
+	opt *cache2.Options
+	h   *log.Helper
 }
 
+// GenerateUserID 基于 redis 计数器生成自增 ID，计数器不存在时从 1 开始，
+// 不能在启动时重置计数器，否则重启或新增实例后会生成已经存在的 ID
 func (r *RedisWorkerImplement) GenerateUserID(ctx context.Context) (uint64, error) {
-	r.once.Do(func() {
-		r.c.Set(ctx, IDKey, StartValue)
-	})
 	id, err := redis.Uint64(r.c.IncrBy(ctx, IDKey, 1))
 	if err != nil {
 		return 0, err
@@ -54,20 +49,30 @@ func (r *RedisWorkerImplement) DisableRead(ctx context.Context, id uint64) error
 }
 
 func (r *RedisWorkerImplement) GetUserByID(ctx context.Context, id uint64) (model.User, error) {
+	val, err := r.getUserValue(ctx, id)
+	if err != nil {
+		return model.User{}, err
+	}
+	return decodeUser(val)
+}
+
+// getUserValue 读取缓存中的原始值，不存在时返回 errcode.CacheMiss
+func (r *RedisWorkerImplement) getUserValue(ctx context.Context, id uint64) (string, error) {
 	key := GenerateKey(id)
 	val, err := r.c.Get(ctx, key)
 	if errors.Is(err, cache2.ErrorCacheMiss) {
-		return model.User{}, errcode.CacheMiss
-	}
-	if err != nil {
-		return model.User{}, err
+		return "", errcode.CacheMiss
 	}
+	return val, err
+}
+
+func decodeUser(val string) (model.User, error) {
 	if val == NullData {
 		return model.User{}, errcode.CacheNullValue
 	}
 
 	user := model.User{}
-	err = user.Read(val)
+	err := user.Read(val)
 	if err != nil {
 		return model.User{}, err
 	}
@@ -0,0 +1,99 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/foundation/DB"
+	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"github.com/TiktokCommence/userService/internal/foundation/segment"
+	"github.com/TiktokCommence/userService/internal/model"
+	"github.com/go-kratos/kratos/v2/log"
+	"time"
+)
+
+var _ biz.GenerateID = (*SegmentIDGenerator)(nil)
+
+const (
+	DefaultSegmentStep   = 1000
+	DefaultSegmentBizTag = "user"
+
+	// 多个实例同时预留号段时乐观锁冲突的重试次数
+	maxSegmentRetries = 5
+)
+
+var ErrorSegmentContention = errors.New("reserve id segment failed after retries")
+
+// SegmentIDGenerator 从 id_segments 表预留号段后在内存中发号，生成的用户 ID 连续且递增，不依赖 redis。
+// 多个实例各自持有不同的号段，实例重启时未发完的号段会被跳过
+type SegmentIDGenerator struct {
+	d     common.DB
+	a     *segment.Allocator
+	h     *log.Helper
+	tag   string
+	step  uint64
+	start uint64
+}
+
+func NewSegmentIDGenerator(c *conf.IDConf, d common.DB, logger log.Logger) (*SegmentIDGenerator, error) {
+	s := &SegmentIDGenerator{
+		d:     d,
+		h:     log.NewHelper(logger),
+		tag:   c.GetSegmentBizTag(),
+		step:  c.GetSegmentStep(),
+		start: c.GetSegmentStart(),
+	}
+	if s.tag == "" {
+		s.tag = DefaultSegmentBizTag
+	}
+	if s.step == 0 {
+		s.step = DefaultSegmentStep
+	}
+	s.a = segment.New(s.reserve, segment.WithErrorHandler(func(err error) {
+		s.h.Warnf("reserve id segment %s error:%v", s.tag, err)
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.a.Warm(ctx); err != nil {
+		return nil, fmt.Errorf("load id segment %s err:%w", s.tag, err)
+	}
+	return s, nil
+}
+
+func (s *SegmentIDGenerator) GenerateUserID(ctx context.Context) (uint64, error) {
+	return s.a.Next(ctx)
+}
+
+// reserve 以 max_id 作为乐观锁把 max_id 增加 step，返回 (旧 max_id, 新 max_id] 范围内的 ID，
+// 记录不存在时以 segmentStart 创建
+func (s *SegmentIDGenerator) reserve(ctx context.Context) (segment.Range, error) {
+	for i := 0; i < maxSegmentRetries; i++ {
+		seg := model.IDSegment{BizTag: s.tag}
+		err := s.d.Query(ctx, &seg, map[string]interface{}{
+			"biz_tag": s.tag,
+		})
+		if errors.Is(err, DB.ErrorDBMiss) {
+			err = s.d.Put(ctx, &model.IDSegment{BizTag: s.tag, MaxID: s.start, Step: s.step})
+			if err != nil && !errors.Is(err, DB.ErrorDBDuplicateEntry) {
+				return segment.Range{}, err
+			}
+			continue
+		}
+		if err != nil {
+			return segment.Range{}, err
+		}
+		n, err := s.d.UpdateColumns(ctx, &model.IDSegment{}, "biz_tag = ? AND max_id = ?", []interface{}{s.tag, seg.MaxID}, map[string]interface{}{
+			"max_id": seg.MaxID + s.step,
+			"step":   s.step,
+		})
+		if err != nil {
+			return segment.Range{}, err
+		}
+		if n == 1 {
+			return segment.Range{Start: seg.MaxID + 1, End: seg.MaxID + s.step + 1}, nil
+		}
+	}
+	return segment.Range{}, ErrorSegmentContention
+}
@@ -0,0 +1,131 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	cache2 "github.com/TiktokCommence/userService/internal/foundation/cache"
+	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"github.com/TiktokCommence/userService/internal/foundation/token"
+	"github.com/TiktokCommence/userService/internal/model"
+	"time"
+)
+
+var _ biz.SessionWorker = (*SessionWorker)(nil)
+
+type SessionWorker struct {
+	c common.Cache
+	// 会话与刷新令牌的有效期保持一致
+	ttl time.Duration
+}
+
+func NewSessionWorker(c common.Cache, m *token.Manager) *SessionWorker {
+	return &SessionWorker{c: c, ttl: m.Options().RefreshTTL}
+}
+
+func (s *SessionWorker) CreateSession(ctx context.Context, userID uint64, roles []string, client model.ClientInfo) (model.Session, error) {
+	id, err := token.RandomString(16)
+	if err != nil {
+		return model.Session{}, err
+	}
+	now := time.Now()
+	session := model.Session{
+		ID:         id,
+		UserID:     userID,
+		Roles:      roles,
+		Device:     client.Device,
+		IP:         client.IP,
+		UserAgent:  client.UserAgent,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	if err = s.save(ctx, session); err != nil {
+		return model.Session{}, fmt.Errorf("create session for user %d err:%w", userID, err)
+	}
+	// 维护用户维度的会话索引，索引的过期时间跟随最新创建的会话
+	indexKey := s.generateIndexKey(userID)
+	if err = s.c.SAdd(ctx, indexKey, id); err != nil {
+		return model.Session{}, fmt.Errorf("index session %s for user %d err:%w", id, userID, err)
+	}
+	if err = s.c.Expire(ctx, indexKey, int64(s.ttl/time.Second)); err != nil {
+		return model.Session{}, fmt.Errorf("expire session index of user %d err:%w", userID, err)
+	}
+	return session, nil
+}
+
+// ListSessions 列出用户所有未过期的会话，顺带清理索引中已过期的会话
+func (s *SessionWorker) ListSessions(ctx context.Context, userID uint64) ([]model.Session, error) {
+	indexKey := s.generateIndexKey(userID)
+	ids, err := s.c.SMembers(ctx, indexKey)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]model.Session, 0, len(ids))
+	var stale []string
+	for _, id := range ids {
+		session, err := s.GetSession(ctx, id)
+		if errors.Is(err, errcode.SessionRevoked) {
+			stale = append(stale, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if len(stale) > 0 {
+		_ = s.c.SRem(ctx, indexKey, stale...)
+	}
+	return sessions, nil
+}
+
+func (s *SessionWorker) GetSession(ctx context.Context, sessionID string) (model.Session, error) {
+	val, err := s.c.Get(ctx, s.generateKey(sessionID))
+	if errors.Is(err, cache2.ErrorCacheMiss) {
+		return model.Session{}, errcode.SessionRevoked
+	}
+	if err != nil {
+		return model.Session{}, err
+	}
+	var session model.Session
+	if err = session.Read(val); err != nil {
+		return model.Session{}, err
+	}
+	return session, nil
+}
+
+func (s *SessionWorker) TouchSession(ctx context.Context, session model.Session, client model.ClientInfo) (model.Session, error) {
+	session.LastSeenAt = time.Now()
+	if client.IP != "" {
+		session.IP = client.IP
+	}
+	if client.UserAgent != "" {
+		session.UserAgent = client.UserAgent
+	}
+	return session, s.save(ctx, session)
+}
+
+func (s *SessionWorker) RevokeSession(ctx context.Context, userID uint64, sessionID string) error {
+	if err := s.c.Del(ctx, s.generateKey(sessionID)); err != nil {
+		return err
+	}
+	return s.c.SRem(ctx, s.generateIndexKey(userID), sessionID)
+}
+
+func (s *SessionWorker) save(ctx context.Context, session model.Session) error {
+	val, err := session.Write()
+	if err != nil {
+		return err
+	}
+	return s.c.SetEx(ctx, s.generateKey(session.ID), val, int64(s.ttl/time.Second))
+}
+
+func (s *SessionWorker) generateKey(sessionID string) string {
+	return fmt.Sprintf("session:%s", sessionID)
+}
+
+func (s *SessionWorker) generateIndexKey(userID uint64) string {
+	return fmt.Sprintf("user_sessions:%d", userID)
+}
@@ -0,0 +1,99 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	"github.com/TiktokCommence/userService/internal/foundation/sms"
+	"github.com/TiktokCommence/userService/internal/foundation/verifycode"
+	"github.com/TiktokCommence/userService/internal/model"
+	"github.com/go-kratos/kratos/v2/log"
+	"strings"
+	"time"
+)
+
+var _ biz.SmsWorker = (*SmsWorker)(nil)
+
+const (
+	SmsBackendLog    = "log"
+	SmsBackendMemory = "memory"
+)
+
+// SmsWorker 与 EmailWorker 共用验证码存储，验证码按用途与手机号隔离
+type SmsWorker struct {
+	s           *verifycode.Store
+	sender      sms.Sender
+	countryCode string
+}
+
+// NewSmsSender 按配置选择短信发送方式，目前只提供写日志与内存两种实现
+func NewSmsSender(c *conf.SmsConf, logger log.Logger) (sms.Sender, error) {
+	switch c.GetBackend() {
+	case "", SmsBackendLog:
+		return sms.NewLogSender(logger), nil
+	case SmsBackendMemory:
+		return sms.NewMemorySender(), nil
+	default:
+		return nil, fmt.Errorf("unsupported sms backend %q", c.GetBackend())
+	}
+}
+
+func NewSmsWorker(s *verifycode.Store, sender sms.Sender, c *conf.SmsConf) *SmsWorker {
+	return &SmsWorker{s: s, sender: sender, countryCode: c.GetDefaultCountryCode()}
+}
+
+// NormalizePhone 把手机号规范化为 E.164 格式
+func (w *SmsWorker) NormalizePhone(phone string) (string, error) {
+	number, err := sms.NormalizeE164(phone, w.countryCode)
+	if err != nil {
+		return "", errcode.PhoneInvalid
+	}
+	return number, nil
+}
+
+// SendCode 生成对应用途的验证码并通过短信发送，返回距离下一次可以发送的时长，
+// 短信发送失败时验证码立即作废并返回错误
+func (w *SmsWorker) SendCode(ctx context.Context, purpose, phone string) (time.Duration, error) {
+	code, err := w.s.Issue(ctx, purpose, phone)
+	var cooldown *verifycode.CooldownError
+	if errors.As(err, &cooldown) {
+		return 0, &errcode.CooldownError{RetryAfter: cooldown.Remaining}
+	}
+	if errors.Is(err, verifycode.ErrorQuotaExceeded) {
+		return 0, errcode.VerifyCodeQuotaExceeded
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err = w.sender.Send(ctx, phone, w.text(ctx, code)); err != nil {
+		if err1 := w.s.Revoke(ctx, purpose, phone); err1 != nil {
+			err = errors.Join(err, err1)
+		}
+		return 0, fmt.Errorf("send %s code to %s err:%w", purpose, phone, err)
+	}
+	return w.s.Options().Cooldown, nil
+}
+
+// VerifyCode 校验对应用途的短信验证码，校验通过或失败次数用尽后验证码失效
+func (w *SmsWorker) VerifyCode(ctx context.Context, purpose, phone, code string) error {
+	err := w.s.Verify(ctx, purpose, phone, code)
+	if errors.Is(err, verifycode.ErrorCodeInvalid) {
+		return errcode.VerifyCodeInvalid
+	}
+	if errors.Is(err, verifycode.ErrorTooManyAttempts) {
+		return errcode.VerifyCodeAttemptsExceeded
+	}
+	return err
+}
+
+// text 短信内容需要尽量短，按语言使用固定格式而不经过邮件模板
+func (w *SmsWorker) text(ctx context.Context, code string) string {
+	minutes := int64(w.s.Options().TTL / time.Minute)
+	if strings.HasPrefix(model.LocaleFromContext(ctx), "en") {
+		return fmt.Sprintf("Your verification code is %s, valid for %d minutes.", code, minutes)
+	}
+	return fmt.Sprintf("验证码%s，%d分钟内有效，请勿泄露给他人。", code, minutes)
+}
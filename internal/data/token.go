@@ -0,0 +1,112 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	cache2 "github.com/TiktokCommence/userService/internal/foundation/cache"
+	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"github.com/TiktokCommence/userService/internal/foundation/token"
+	"github.com/TiktokCommence/userService/internal/model"
+	"time"
+)
+
+var _ biz.TokenWorker = (*TokenWorker)(nil)
+
+type TokenWorker struct {
+	c common.Cache
+	m *token.Manager
+}
+
+func NewTokenWorker(c common.Cache, m *token.Manager) *TokenWorker {
+	return &TokenWorker{c: c, m: m}
+}
+
+func (t *TokenWorker) IssueAccessToken(userID uint64, sessionID string, roles []string) (string, model.TokenClaims, error) {
+	signed, claims, err := t.m.Issue(userID, sessionID, roles)
+	if err != nil {
+		return "", model.TokenClaims{}, err
+	}
+	return signed, toTokenClaims(claims, userID), nil
+}
+
+func (t *TokenWorker) ParseAccessToken(accessToken string) (model.TokenClaims, error) {
+	claims, err := t.m.Parse(accessToken)
+	if errors.Is(err, token.ErrorTokenExpired) {
+		return model.TokenClaims{}, errcode.TokenExpired
+	}
+	if err != nil {
+		return model.TokenClaims{}, errcode.TokenInvalid
+	}
+	userID, err := claims.UserID()
+	if err != nil {
+		return model.TokenClaims{}, errcode.TokenInvalid
+	}
+	return toTokenClaims(claims, userID), nil
+}
+
+func (t *TokenWorker) CreateRefreshToken(ctx context.Context, userID uint64, sessionID string) (string, time.Time, error) {
+	refreshToken, err := token.RandomString(32)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	ttl := t.m.Options().RefreshTTL
+	rt := model.RefreshToken{UserID: userID, SessionID: sessionID, ExpiresAt: time.Now().Add(ttl)}
+	val, err := rt.Write()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	// 缓存中只保存令牌的摘要，缓存泄露时无法直接使用
+	err = t.c.SetEx(ctx, t.generateKey(refreshToken), val, int64(ttl/time.Second))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("save refresh token of user %d err:%w", userID, err)
+	}
+	return refreshToken, rt.ExpiresAt, nil
+}
+
+func (t *TokenWorker) ConsumeRefreshToken(ctx context.Context, refreshToken string) (model.RefreshToken, error) {
+	val, err := t.c.GetDel(ctx, t.generateKey(refreshToken))
+	if errors.Is(err, cache2.ErrorCacheMiss) {
+		return model.RefreshToken{}, errcode.TokenInvalid
+	}
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	var rt model.RefreshToken
+	if err = rt.Read(val); err != nil {
+		return model.RefreshToken{}, err
+	}
+	if time.Now().After(rt.ExpiresAt) {
+		return model.RefreshToken{}, errcode.TokenExpired
+	}
+	return rt, nil
+}
+
+func (t *TokenWorker) JWKS() []model.JWK {
+	jwks := t.m.JWKS()
+	keys := make([]model.JWK, 0, len(jwks))
+	for _, k := range jwks {
+		keys = append(keys, model.JWK{Kty: k.Kty, Crv: k.Crv, X: k.X, N: k.N, E: k.E, Kid: k.Kid, Alg: k.Alg, Use: k.Use})
+	}
+	return keys
+}
+
+func (t *TokenWorker) generateKey(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return fmt.Sprintf("refresh_token:%s", hex.EncodeToString(sum[:]))
+}
+
+func toTokenClaims(claims *token.Claims, userID uint64) model.TokenClaims {
+	return model.TokenClaims{
+		ID:        claims.ID,
+		UserID:    userID,
+		SessionID: claims.SessionID,
+		Roles:     claims.Roles,
+		IssuedAt:  claims.IssuedAt.Time,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+}
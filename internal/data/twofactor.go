@@ -0,0 +1,137 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	"github.com/TiktokCommence/userService/internal/foundation/DB"
+	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"github.com/TiktokCommence/userService/internal/foundation/totp"
+	"github.com/TiktokCommence/userService/internal/model"
+	"time"
+)
+
+var (
+	_ biz.TwoFactorRepo = (*TwoFactorRepo)(nil)
+	_ biz.TOTPWorker    = (*TOTPWorker)(nil)
+)
+
+const (
+	DefaultTOTPIssuer        = "TiktokCommence"
+	DefaultTOTPSkew          = 1
+	DefaultRecoveryCodeCount = 10
+)
+
+type TwoFactorRepo struct {
+	d common.DB
+}
+
+func NewTwoFactorRepo(d common.DB) *TwoFactorRepo {
+	return &TwoFactorRepo{d: d}
+}
+
+func (t *TwoFactorRepo) GetTwoFactor(ctx context.Context, userID uint64) (model.TwoFactor, error) {
+	tf := model.TwoFactor{UserID: userID}
+	err := t.d.Query(ctx, &tf, map[string]interface{}{
+		"user_id": userID,
+	})
+	if errors.Is(err, DB.ErrorDBMiss) {
+		return tf, errcode.TwoFactorNotEnrolled
+	}
+	return tf, err
+}
+
+// SaveTwoFactor 不存在时创建，存在时更新非零值字段
+func (t *TwoFactorRepo) SaveTwoFactor(ctx context.Context, tf model.TwoFactor) error {
+	err := t.d.Put(ctx, &tf)
+	if errors.Is(err, DB.ErrorDBDuplicateEntry) {
+		err = t.d.Update(ctx, &tf)
+	}
+	if err != nil {
+		return fmt.Errorf("save two factor of user %d err:%w", tf.UserID, err)
+	}
+	return nil
+}
+
+// UseStep 以 last_used_step 作为条件更新，时间片不大于已使用的时间片时不更新
+func (t *TwoFactorRepo) UseStep(ctx context.Context, userID uint64, step int64) (bool, error) {
+	n, err := t.d.UpdateColumns(ctx, &model.TwoFactor{}, "user_id = ? AND last_used_step < ?", []interface{}{userID, step}, map[string]interface{}{
+		"last_used_step": step,
+	})
+	if err != nil {
+		return false, fmt.Errorf("use totp step of user %d err:%w", userID, err)
+	}
+	return n == 1, nil
+}
+
+// ReplaceRecoveryCodes 以原有的恢复码摘要作为条件更新，期间被其他请求修改过时不更新
+func (t *TwoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, userID uint64, old, new string) (bool, error) {
+	n, err := t.d.UpdateColumns(ctx, &model.TwoFactor{}, "user_id = ? AND recovery_codes = ?", []interface{}{userID, old}, map[string]interface{}{
+		"recovery_codes": new,
+	})
+	if err != nil {
+		return false, fmt.Errorf("use recovery code of user %d err:%w", userID, err)
+	}
+	return n == 1, nil
+}
+
+func (t *TwoFactorRepo) DeleteTwoFactor(ctx context.Context, userID uint64) error {
+	return t.d.Delete(ctx, &model.TwoFactor{}, map[string]interface{}{
+		"user_id": userID,
+	})
+}
+
+type TOTPWorker struct {
+	issuer            string
+	skew              int
+	recoveryCodeCount int
+}
+
+func NewTOTPWorker(c *conf.TwoFactorConf) *TOTPWorker {
+	w := &TOTPWorker{
+		issuer:            c.GetIssuer(),
+		skew:              int(c.GetSkew()),
+		recoveryCodeCount: int(c.GetRecoveryCodeCount()),
+	}
+	if w.issuer == "" {
+		w.issuer = DefaultTOTPIssuer
+	}
+	if w.skew <= 0 {
+		w.skew = DefaultTOTPSkew
+	}
+	if w.recoveryCodeCount <= 0 {
+		w.recoveryCodeCount = DefaultRecoveryCodeCount
+	}
+	return w
+}
+
+func (w *TOTPWorker) GenerateSecret(account string) (string, string, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	return secret, totp.URI(w.issuer, account, secret), nil
+}
+
+func (w *TOTPWorker) Validate(secret, code string) (int64, bool) {
+	return totp.Validate(secret, code, time.Now(), w.skew)
+}
+
+func (w *TOTPWorker) GenerateRecoveryCodes() ([]string, []string, error) {
+	codes, err := totp.GenerateRecoveryCodes(w.recoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, totp.HashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+func (w *TOTPWorker) HashRecoveryCode(code string) string {
+	return totp.HashRecoveryCode(code)
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"context"
+	"github.com/TiktokCommence/userService/internal/biz"
+	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/foundation/localcache"
+	"github.com/TiktokCommence/userService/internal/model"
+	"github.com/go-kratos/kratos/v2/log"
+	"strconv"
+	"sync/atomic"
+)
+
+var _ biz.RedisWorker = (*LocalUserCache)(nil)
+
+// UserInvalidationChannel 用户缓存被删除时广播用户 ID 的 channel
+const UserInvalidationChannel = "user_cache_invalidation"
+
+// LocalUserCache 在 redis 之前增加进程内缓存，只在读到 redis 中的值时写入。
+// 删除用户缓存时通过 redis 发布订阅通知所有实例删除进程内的副本，订阅断开期间不使用进程内缓存
+type LocalUserCache struct {
+	*RedisWorkerImplement
+	l *localcache.Cache[uint64, string]
+	h *log.Helper
+	// 订阅成功后才读写进程内缓存，订阅断开期间可能错过失效通知
+	subscribed atomic.Bool
+	// 每次失效加一，读 redis 期间发生过失效时不写入进程内缓存，避免写入刚被删除的旧值
+	gen atomic.Uint64
+}
+
+func NewLocalUserCache(c *conf.CacheConf, r *RedisWorkerImplement, logger log.Logger) *LocalUserCache {
+	u := &LocalUserCache{RedisWorkerImplement: r, h: log.NewHelper(logger)}
+	if c.GetLocal() {
+		u.l = localcache.New[uint64, string](int(c.GetLocalSize()), c.GetLocalTTL().AsDuration())
+	}
+	return u
+}
+
+func (u *LocalUserCache) GetUserByID(ctx context.Context, id uint64) (model.User, error) {
+	if !u.usable() {
+		return u.RedisWorkerImplement.GetUserByID(ctx, id)
+	}
+	if val, ok := u.l.Get(id); ok {
+		return decodeUser(val)
+	}
+	gen := u.gen.Load()
+	val, err := u.getUserValue(ctx, id)
+	if err != nil {
+		return model.User{}, err
+	}
+	if u.gen.Load() == gen {
+		u.l.Set(id, val)
+	}
+	return decodeUser(val)
+}
+
+// DeleteUser 删除 redis 中的缓存后广播失效通知，本实例的副本直接删除
+func (u *LocalUserCache) DeleteUser(ctx context.Context, id uint64) error {
+	if u.l == nil {
+		return u.RedisWorkerImplement.DeleteUser(ctx, id)
+	}
+	u.invalidate(id)
+	if err := u.RedisWorkerImplement.DeleteUser(ctx, id); err != nil {
+		return err
+	}
+	if err := u.c.Publish(ctx, UserInvalidationChannel, strconv.FormatUint(id, 10)); err != nil {
+		u.h.Warnf("publish invalidation of user %d error:%v", id, err)
+	}
+	return nil
+}
+
+// Listen 订阅失效通知直到 ctx 取消或连接断开，返回后清空进程内缓存并停止使用，直到重新订阅
+func (u *LocalUserCache) Listen(ctx context.Context) error {
+	if u.l == nil {
+		<-ctx.Done()
+		return nil
+	}
+	u.subscribed.Store(true)
+	defer func() {
+		u.subscribed.Store(false)
+		u.gen.Add(1)
+		u.l.Purge()
+	}()
+	return u.c.Subscribe(ctx, UserInvalidationChannel, func(message string) {
+		id, err := strconv.ParseUint(message, 10, 64)
+		if err != nil {
+			u.h.Warnf("invalid user cache invalidation %q", message)
+			return
+		}
+		u.invalidate(id)
+	})
+}
+
+// Stats 返回进程内缓存的命中统计，未开启时全部为 0
+func (u *LocalUserCache) Stats() localcache.Stats {
+	if u.l == nil {
+		return localcache.Stats{}
+	}
+	return u.l.Stats()
+}
+
+func (u *LocalUserCache) invalidate(id uint64) {
+	u.gen.Add(1)
+	u.l.Delete(id)
+}
+
+func (u *LocalUserCache) usable() bool {
+	return u.l != nil && u.subscribed.Load()
+}
@@ -1,10 +1,62 @@
 package errcode
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	UserAlreadyExists = errors.New("user already exists")
 	UserNotFound      = errors.New("user not found in db")
+	EmailInvalid      = errors.New("email is invalid")
 	CacheMiss         = errors.New("cache miss")
 	CacheNullValue    = errors.New("cache null value")
+	PasswordIncorrect = errors.New("password incorrect")
+	TokenInvalid      = errors.New("token is invalid")
+	TokenExpired      = errors.New("token is expired")
+	SessionRevoked    = errors.New("session is revoked or expired")
+	VerifyCodeInvalid = errors.New("verify code is invalid or expired")
+	// 验证码校验次数用尽，需要重新发送
+	VerifyCodeAttemptsExceeded = errors.New("verify code attempts exceeded")
+	VerifyCodeCooldown         = errors.New("verify code was sent too recently")
+	VerifyCodeQuotaExceeded    = errors.New("daily verify code quota exceeded")
+	VerifyCodeNotExposed       = errors.New("verify codes are not exposed")
+	AccountLocked              = errors.New("account is locked")
+	// 二次验证
+	TwoFactorNotEnrolled    = errors.New("two factor is not enrolled")
+	TwoFactorAlreadyEnabled = errors.New("two factor is already enabled")
+	TwoFactorCodeInvalid    = errors.New("two factor code is invalid")
+	ChallengeInvalid        = errors.New("login challenge is invalid or expired")
+	// 手机号
+	PhoneInvalid     = errors.New("phone number is invalid")
+	PhoneAlreadyUsed = errors.New("phone number is already verified by another user")
+	// 没有待确认的邮箱修改，或者确认的邮箱与申请的不一致
+	EmailChangeNotRequested = errors.New("email change is not requested")
 )
+
+// LockedError 账号或 IP 因登录失败次数过多被锁定，RetryAfter 为剩余的锁定时长
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", AccountLocked, e.RetryAfter)
+}
+
+func (e *LockedError) Is(target error) bool {
+	return target == AccountLocked
+}
+
+// CooldownError 验证码发送过于频繁，RetryAfter 为距离下一次可以发送的时长
+type CooldownError struct {
+	RetryAfter time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", VerifyCodeCooldown, e.RetryAfter)
+}
+
+func (e *CooldownError) Is(target error) bool {
+	return target == VerifyCodeCooldown
+}
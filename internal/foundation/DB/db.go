@@ -138,6 +138,48 @@ func (d *DB) Exist(ctx context.Context, obj common.Object, params map[string]int
 	return cnt > 0, nil
 }
 
+func (d *DB) Find(ctx context.Context, obj common.Object, objs interface{}, query string, args []interface{}, order string, limit int) error {
+	db := d.db
+	tabler, ok := obj.(tabler)
+	if !ok {
+		return ErrorDBLocateTable
+	}
+	db = db.Table(tabler.TableName()).WithContext(ctx).Where(query, args...)
+	if order != "" {
+		db = db.Order(order)
+	}
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+	return db.Find(objs).Error
+}
+
+func (d *DB) UpdateColumns(ctx context.Context, obj common.Object, query string, args []interface{}, values map[string]interface{}) (int64, error) {
+	db := d.db
+	tabler, ok := obj.(tabler)
+	if !ok {
+		return 0, ErrorDBLocateTable
+	}
+	res := db.Table(tabler.TableName()).WithContext(ctx).Where(query, args...).Updates(values)
+	return res.RowsAffected, res.Error
+}
+
+func (d *DB) DeleteWhere(ctx context.Context, obj common.Object, query string, args []interface{}) (int64, error) {
+	db := d.db
+	tabler, ok := obj.(tabler)
+	if !ok {
+		return 0, ErrorDBLocateTable
+	}
+	res := db.Table(tabler.TableName()).WithContext(ctx).Where(query, args...).Delete(obj)
+	return res.RowsAffected, res.Error
+}
+
+func (d *DB) Transaction(ctx context.Context, fn func(tx common.DB) error) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&DB{db: tx, opt: d.opt})
+	})
+}
+
 func (d *DB) checkParams(params map[string]interface{}) (bool, error) {
 	if params == nil {
 		return false, errors.New("the map is nil and considered empty")
@@ -21,6 +21,13 @@ type Client interface {
 	PExpire(ctx context.Context, key string, expireMilis int64) error
 	Set(ctx context.Context, key string, value interface{}) error
 	IncrBy(ctx context.Context, key string, step int64) (int64, error)
+	GetDel(ctx context.Context, key string) (string, error)
+	Expire(ctx context.Context, key string, expireSeconds int64) error
+	SAdd(ctx context.Context, key string, members ...string) error
+	SRem(ctx context.Context, key string, members ...string) error
+	SMembers(ctx context.Context, key string) ([]string, error)
+	Publish(ctx context.Context, channel, message string) error
+	Subscribe(ctx context.Context, channel string, handle func(message string)) error
 }
 
 // redis 实现版本的缓存模块
@@ -90,8 +97,83 @@ func (c *Cache) SetEx(ctx context.Context, key, value string, expireSeconds int6
 	return c.client.SetEx(ctx, key, value, expireSeconds)
 }
 
+// 原子性地读取并删除 key 对应缓存
+func (c *Cache) GetDel(ctx context.Context, key string) (string, error) {
+	reply, err := c.client.GetDel(ctx, key)
+	if err != nil && !errors.Is(err, redis.ErrNil) {
+		return "", err
+	}
+	if errors.Is(err, redis.ErrNil) {
+		return "", ErrorCacheMiss
+	}
+	return reply, nil
+}
+
+func (c *Cache) Expire(ctx context.Context, key string, expireSeconds int64) error {
+	return c.client.Expire(ctx, key, expireSeconds)
+}
+
+func (c *Cache) SAdd(ctx context.Context, key string, members ...string) error {
+	return c.client.SAdd(ctx, key, members...)
+}
+
+func (c *Cache) SRem(ctx context.Context, key string, members ...string) error {
+	return c.client.SRem(ctx, key, members...)
+}
+
+func (c *Cache) SMembers(ctx context.Context, key string) ([]string, error) {
+	return c.client.SMembers(ctx, key)
+}
+
+// key 不存在时写入并设置过期时间，返回是否写入成功
+func (c *Cache) SetNX(ctx context.Context, key, value string, expireSeconds int64) (bool, error) {
+	reply, err := c.client.Eval(ctx, LuaSetNX, 1, []interface{}{key, value, expireSeconds})
+	if err != nil {
+		return false, err
+	}
+	return cast.ToInt(reply) == 1, nil
+}
+
+// key 的值与 value 相同时才续期，返回是否续期成功
+func (c *Cache) ExpireIfEqual(ctx context.Context, key, value string, expireSeconds int64) (bool, error) {
+	reply, err := c.client.Eval(ctx, LuaExpireIfEqual, 1, []interface{}{key, value, expireSeconds})
+	if err != nil {
+		return false, err
+	}
+	return cast.ToInt(reply) == 1, nil
+}
+
+// key 的值与 value 相同时才删除，返回是否删除成功
+func (c *Cache) DelIfEqual(ctx context.Context, key, value string) (bool, error) {
+	reply, err := c.client.Eval(ctx, LuaDelIfEqual, 1, []interface{}{key, value})
+	if err != nil {
+		return false, err
+	}
+	return cast.ToInt(reply) == 1, nil
+}
+
+// 向 channel 广播消息，只有正在订阅的实例能够收到
+func (c *Cache) Publish(ctx context.Context, channel, message string) error {
+	return c.client.Publish(ctx, channel, message)
+}
+
+// 订阅 channel 直到 ctx 取消或连接断开
+func (c *Cache) Subscribe(ctx context.Context, channel string, handle func(message string)) error {
+	return c.client.Subscribe(ctx, channel, handle)
+}
+
 // 基于 key 映射得到 v key 表达式
 func (c *Cache) disableKey(key string) string {
 	// 通过 {hash_tag}，保证在 redis 集群模式下，key 和 disable key 也会被分发到相同节点
 	return fmt.Sprintf("Enable_Lock_Key_{%s}", key)
 }
+
+// countKey 计数加一，第一次计数时设置过期时间；计数达到 threshold 时清空计数并将 levelKey 等级加一，
+// 返回新的等级，未达到阈值时返回 0。整个过程在一个脚本中完成，并发调用时每次达到阈值只会升级一次
+func (c *Cache) IncrEscalate(ctx context.Context, countKey, levelKey string, threshold, countExpireSeconds, levelExpireSeconds int64) (int64, error) {
+	reply, err := c.client.Eval(ctx, LuaIncrEscalate, 2, []interface{}{countKey, levelKey, threshold, countExpireSeconds, levelExpireSeconds})
+	if err != nil {
+		return 0, err
+	}
+	return cast.ToInt64(reply), nil
+}
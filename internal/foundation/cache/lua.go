@@ -14,5 +14,42 @@ const (
 	local cache_expire_seconds = tonumber(ARGV[2]);
 	redis.call("expire",key,cache_expire_seconds);
 	return 1;
+`
+	// key 不存在时写入并设置过期时间，写入成功返回 1
+	LuaSetNX = `
+	local reply = redis.call("set",KEYS[1],ARGV[1],"NX","EX",tonumber(ARGV[2]));
+	if reply then
+	    return 1;
+	end
+	return 0;
+`
+	// key 的值与 ARGV[1] 相同时才续期，用于持有者续约
+	LuaExpireIfEqual = `
+	if redis.call("get",KEYS[1]) == ARGV[1] then
+	    return redis.call("expire",KEYS[1],tonumber(ARGV[2]));
+	end
+	return 0;
+`
+	// key 的值与 ARGV[1] 相同时才删除，用于持有者主动释放
+	LuaDelIfEqual = `
+	if redis.call("get",KEYS[1]) == ARGV[1] then
+	    return redis.call("del",KEYS[1]);
+	end
+	return 0;
+`
+	// KEYS[1] 计数加一，第一次计数时设置过期时间 ARGV[2]；计数达到阈值 ARGV[1] 时清空计数，
+	// KEYS[2] 等级加一并设置过期时间 ARGV[3]，返回新的等级，未达到阈值时返回 0
+	LuaIncrEscalate = `
+	local count = redis.call("incr",KEYS[1]);
+	if count == 1 then
+	    redis.call("expire",KEYS[1],tonumber(ARGV[2]));
+	end
+	if count < tonumber(ARGV[1]) then
+	    return 0;
+	end
+	redis.call("del",KEYS[1]);
+	local level = redis.call("incr",KEYS[2]);
+	redis.call("expire",KEYS[2],tonumber(ARGV[3]));
+	return level;
 `
 )
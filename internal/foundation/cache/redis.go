@@ -150,3 +150,113 @@ func (r *RClient) IncrBy(ctx context.Context, key string, step int64) (int64, er
 	}
 	return val, nil
 }
+
+func (r *RClient) GetDel(ctx context.Context, key string) (string, error) {
+	if key == "" {
+		return "", errors.New("redis GETDEL key can't be empty")
+	}
+	conn, err := r.pool.GetContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	return redis.String(conn.Do("GETDEL", key))
+}
+
+func (r *RClient) Expire(ctx context.Context, key string, expireSeconds int64) error {
+	conn, err := r.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("EXPIRE", key, expireSeconds)
+	return err
+}
+
+func (r *RClient) SAdd(ctx context.Context, key string, members ...string) error {
+	if key == "" {
+		return errors.New("redis SADD key can't be empty")
+	}
+	conn, err := r.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("SADD", redis.Args{}.Add(key).AddFlat(members)...)
+	return err
+}
+
+func (r *RClient) SRem(ctx context.Context, key string, members ...string) error {
+	if key == "" {
+		return errors.New("redis SREM key can't be empty")
+	}
+	conn, err := r.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("SREM", redis.Args{}.Add(key).AddFlat(members)...)
+	return err
+}
+
+func (r *RClient) SMembers(ctx context.Context, key string) ([]string, error) {
+	conn, err := r.pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return redis.Strings(conn.Do("SMEMBERS", key))
+}
+
+func (r *RClient) Publish(ctx context.Context, channel, message string) error {
+	conn, err := r.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("PUBLISH", channel, message)
+	return err
+}
+
+// Subscribe 使用单独的连接订阅 channel，收到消息时调用 handle，
+// ctx 取消时返回 nil，连接断开时返回错误，由调用方决定是否重新订阅
+func (r *RClient) Subscribe(ctx context.Context, channel string, handle func(message string)) error {
+	// 订阅中的连接不能归还连接池，直接建立新连接
+	conn, err := r.pool.Dial()
+	if err != nil {
+		return err
+	}
+	psc := redis.PubSubConn{Conn: conn}
+	if err = psc.Subscribe(channel); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			// 关闭连接使阻塞中的 Receive 返回
+			_ = conn.Close()
+		case <-stop:
+			_ = conn.Close()
+		}
+	}()
+	for {
+		switch v := psc.Receive().(type) {
+		case redis.Message:
+			handle(string(v.Data))
+		case error:
+			if ctx.Err() != nil {
+				return nil
+			}
+			return v
+		}
+	}
+}
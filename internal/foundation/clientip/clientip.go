@@ -0,0 +1,94 @@
+package clientip
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Resolver 根据直连地址与转发头确定客户端地址。
+// 只有直连地址属于受信代理时才读取转发头，并从右往左跳过受信代理，取第一个不受信的地址，
+// 客户端自己填写的 x-forwarded-for 只会出现在最左侧，无法伪造来源
+type Resolver struct {
+	trusted []netip.Prefix
+}
+
+// New proxies 为受信代理的 IP 或 CIDR，为空时总是使用直连地址
+func New(proxies []string) (*Resolver, error) {
+	r := &Resolver{}
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			addr, err := netip.ParseAddr(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q:%w", p, err)
+			}
+			r.trusted = append(r.trusted, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q:%w", p, err)
+		}
+		r.trusted = append(r.trusted, prefix.Masked())
+	}
+	return r, nil
+}
+
+// Resolve remoteAddr 为连接的对端地址，可以带端口；forwardedFor、realIP 为请求头 x-forwarded-for 与 x-real-ip
+func (r *Resolver) Resolve(remoteAddr, forwardedFor, realIP string) string {
+	peer, ok := parse(remoteAddr)
+	if !ok {
+		return remoteAddr
+	}
+	if !r.isTrusted(peer) {
+		return peer.String()
+	}
+	hops := strings.Split(forwardedFor, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		addr, ok := parse(hop)
+		if !ok {
+			// 无法解析的地址之后的内容都不可信，使用最后一个受信的地址
+			return peer.String()
+		}
+		if !r.isTrusted(addr) {
+			return addr.String()
+		}
+		peer = addr
+	}
+	if strings.TrimSpace(forwardedFor) == "" {
+		if addr, ok := parse(strings.TrimSpace(realIP)); ok {
+			return addr.String()
+		}
+	}
+	return peer.String()
+}
+
+func (r *Resolver) isTrusted(addr netip.Addr) bool {
+	for _, p := range r.trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parse 解析可能带端口的地址
+func parse(s string) (netip.Addr, bool) {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
@@ -0,0 +1,41 @@
+package clientip
+
+import "testing"
+
+func TestResolver_Resolve(t *testing.T) {
+	r, err := New([]string{"10.0.0.0/8", "192.168.1.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		remote, forwarded, realIP string
+		expect                    string
+	}{
+		// 直连地址不受信时忽略转发头
+		{"1.2.3.4:5678", "9.9.9.9", "8.8.8.8", "1.2.3.4"},
+		// 取最右侧不受信的地址，客户端伪造的最左侧地址被忽略
+		{"10.0.0.2:80", "6.6.6.6, 1.2.3.4, 10.0.0.3", "", "1.2.3.4"},
+		{"192.168.1.1:80", "1.2.3.4", "", "1.2.3.4"},
+		// 全部是受信代理时使用最左侧的地址
+		{"10.0.0.2:80", "10.0.0.5,10.0.0.3", "", "10.0.0.5"},
+		// 无法解析的地址之后的内容都不可信
+		{"10.0.0.2:80", "1.2.3.4, bogus, 10.0.0.3", "", "10.0.0.3"},
+		{"10.0.0.2:80", "", "1.2.3.4", "1.2.3.4"},
+		{"10.0.0.2:80", "", "", "10.0.0.2"},
+		{"[::ffff:10.0.0.2]:80", "1.2.3.4", "", "1.2.3.4"},
+	}
+	for _, c := range cases {
+		if got := r.Resolve(c.remote, c.forwarded, c.realIP); got != c.expect {
+			t.Errorf("Resolve(%q, %q, %q) = %q, want %q", c.remote, c.forwarded, c.realIP, got, c.expect)
+		}
+	}
+
+	// 没有受信代理时总是使用直连地址
+	r, _ = New(nil)
+	if got := r.Resolve("10.0.0.2:80", "1.2.3.4", "1.2.3.4"); got != "10.0.0.2" {
+		t.Errorf("expected remote address without trusted proxies, got %q", got)
+	}
+	if _, err = New([]string{"not-an-ip"}); err == nil {
+		t.Error("expected invalid proxy to be rejected")
+	}
+}
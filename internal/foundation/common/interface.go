@@ -21,6 +21,23 @@ type Cache interface {
 
 	IncrBy(ctx context.Context, key string, step int64) (int64, error)
 	SetEx(ctx context.Context, key, value string, expireSeconds int64) error
+	// 读取并删除 key 对应缓存，保证只有一个调用方能够读到
+	GetDel(ctx context.Context, key string) (string, error)
+	// 设置 key 的过期时间
+	Expire(ctx context.Context, key string, expireSeconds int64) error
+	// 集合操作
+	SAdd(ctx context.Context, key string, members ...string) error
+	SRem(ctx context.Context, key string, members ...string) error
+	SMembers(ctx context.Context, key string) ([]string, error)
+	// 租约操作：key 不存在时写入，值相同时才续期或删除，保证只有持有者能够续约、释放
+	SetNX(ctx context.Context, key, value string, expireSeconds int64) (bool, error)
+	ExpireIfEqual(ctx context.Context, key, value string, expireSeconds int64) (bool, error)
+	DelIfEqual(ctx context.Context, key, value string) (bool, error)
+	// 计数达到阈值时清空计数并升级，返回新的等级，未达到阈值时返回 0
+	IncrEscalate(ctx context.Context, countKey, levelKey string, threshold, countExpireSeconds, levelExpireSeconds int64) (int64, error)
+	// 发布订阅：订阅阻塞直到 ctx 取消或连接断开，消息不持久化，订阅断开期间的消息会丢失
+	Publish(ctx context.Context, channel, message string) error
+	Subscribe(ctx context.Context, channel string, handle func(message string)) error
 }
 
 // 数据库模块的抽象接口定义
@@ -35,6 +52,14 @@ type DB interface {
 	Update(ctx context.Context, obj Object) error
 
 	Exist(ctx context.Context, obj Object, params map[string]interface{}) (bool, error)
+	// 按条件查询多条记录写入 objs（切片指针），obj 用于定位数据表，limit 不大于 0 时不限制条数
+	Find(ctx context.Context, obj Object, objs interface{}, query string, args []interface{}, order string, limit int) error
+	// 按条件更新指定字段，返回受影响的行数，调用方可以据此实现乐观锁
+	UpdateColumns(ctx context.Context, obj Object, query string, args []interface{}, values map[string]interface{}) (int64, error)
+	// 按条件删除多条记录，返回删除的行数
+	DeleteWhere(ctx context.Context, obj Object, query string, args []interface{}) (int64, error)
+	// 在事务中执行 fn，fn 中的读写需要使用传入的 tx，fn 返回错误时回滚
+	Transaction(ctx context.Context, fn func(tx DB) error) error
 }
 
 // 每次读写操作时，操作的一笔数据记录
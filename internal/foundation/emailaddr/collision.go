@@ -0,0 +1,59 @@
+package emailaddr
+
+import "sort"
+
+// Record 已存储的一个账号邮箱
+type Record struct {
+	ID    uint64
+	Email string
+}
+
+// Collision 规范形式相同的多个账号，需要人工合并或修改其中一个
+type Collision struct {
+	Canonical string
+	Records   []Record
+}
+
+// Rewrite 没有冲突、但存储的不是规范形式的账号，可以直接改写
+type Rewrite struct {
+	Record
+	Canonical string
+}
+
+type Report struct {
+	Collisions []Collision
+	Rewrites   []Rewrite
+	// 无法规范化的邮箱
+	Invalid []Record
+}
+
+// Plan 按规范形式对已有邮箱分组，找出冲突与需要改写的记录，结果按规范形式与 ID 排序
+func (c *Canonicalizer) Plan(records []Record) Report {
+	var report Report
+	groups := make(map[string][]Record)
+	for _, r := range records {
+		canonical, err := c.Canonicalize(r.Email)
+		if err != nil {
+			report.Invalid = append(report.Invalid, r)
+			continue
+		}
+		groups[canonical] = append(groups[canonical], r)
+	}
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		group := groups[k]
+		sort.Slice(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+		if len(group) > 1 {
+			report.Collisions = append(report.Collisions, Collision{Canonical: k, Records: group})
+			continue
+		}
+		if group[0].Email != k {
+			report.Rewrites = append(report.Rewrites, Rewrite{Record: group[0], Canonical: k})
+		}
+	}
+	return report
+}
@@ -0,0 +1,92 @@
+package emailaddr
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+var ErrorInvalidEmail = errors.New("email address is invalid")
+
+const (
+	maxLocalLength = 64
+	// 与 users.email 列的长度一致
+	MaxLength = 100
+)
+
+// 部分邮箱服务商忽略本地部分的点号或 + 后缀，开启服务商规则后这些写法会被视为同一个地址
+type providerRule struct {
+	domain     string // 规范化后使用的域名
+	ignoreDots bool
+	plusTag    bool
+}
+
+var providers = map[string]providerRule{
+	"gmail.com":      {domain: "gmail.com", ignoreDots: true, plusTag: true},
+	"googlemail.com": {domain: "gmail.com", ignoreDots: true, plusTag: true},
+	"outlook.com":    {domain: "outlook.com", plusTag: true},
+	"hotmail.com":    {domain: "hotmail.com", plusTag: true},
+	"live.com":       {domain: "live.com", plusTag: true},
+}
+
+type Canonicalizer struct {
+	providerRules bool
+}
+
+type Option func(*Canonicalizer)
+
+// WithProviderRules 开启服务商规则，例如 Gmail 地址去掉点号与 + 后缀
+func WithProviderRules(enable bool) Option {
+	return func(c *Canonicalizer) {
+		c.providerRules = enable
+	}
+}
+
+func New(opts ...Option) *Canonicalizer {
+	c := &Canonicalizer{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Canonicalize 返回邮箱的规范形式：去掉首尾空白，本地部分与域名转为小写，
+// 国际化域名转为 punycode，开启服务商规则时再按服务商规则处理本地部分
+func (c *Canonicalizer) Canonicalize(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	at := strings.LastIndexByte(raw, '@')
+	if at <= 0 || at == len(raw)-1 {
+		return "", ErrorInvalidEmail
+	}
+	local := strings.ToLower(raw[:at])
+	domain, err := idna.Lookup.ToASCII(strings.TrimSuffix(raw[at+1:], "."))
+	if err != nil || !strings.Contains(domain, ".") {
+		return "", ErrorInvalidEmail
+	}
+	if c.providerRules {
+		if rule, ok := providers[domain]; ok {
+			local, domain = rule.apply(local), rule.domain
+		}
+	}
+	if local == "" || len(local) > maxLocalLength || strings.ContainsAny(local, " \t\r\n<>()[],;:\\\"@") {
+		return "", ErrorInvalidEmail
+	}
+	email := local + "@" + domain
+	if len(email) > MaxLength {
+		return "", ErrorInvalidEmail
+	}
+	return email, nil
+}
+
+func (r providerRule) apply(local string) string {
+	if r.plusTag {
+		if i := strings.IndexByte(local, '+'); i > 0 {
+			local = local[:i]
+		}
+	}
+	if r.ignoreDots {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	return local
+}
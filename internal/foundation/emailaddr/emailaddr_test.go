@@ -0,0 +1,66 @@
+package emailaddr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	cases := []struct {
+		raw      string
+		provider bool
+		want     string
+		err      error
+	}{
+		{"  Foo@QQ.com ", false, "foo@qq.com", nil},
+		{"foo@qq.com.", false, "foo@qq.com", nil},
+		{"User@München.DE", false, "user@xn--mnchen-3ya.de", nil},
+		{"José@Bücher.DE", false, "josé@xn--bcher-kva.de", nil},
+		{"F.o.o+news@GoogleMail.com", false, "f.o.o+news@googlemail.com", nil},
+		{"F.o.o+news@GoogleMail.com", true, "foo@gmail.com", nil},
+		{"first.last+tag@outlook.com", true, "first.last@outlook.com", nil},
+		{"first.last+tag@qq.com", true, "first.last+tag@qq.com", nil},
+		{"+tag@gmail.com", true, "+tag@gmail.com", nil},
+		{"foo", false, "", ErrorInvalidEmail},
+		{"@qq.com", false, "", ErrorInvalidEmail},
+		{"foo@", false, "", ErrorInvalidEmail},
+		{"foo@localhost", false, "", ErrorInvalidEmail},
+		{"fo o@qq.com", false, "", ErrorInvalidEmail},
+		{"foo@bar@qq.com", false, "", ErrorInvalidEmail},
+		{"foo@-qq.com", false, "", ErrorInvalidEmail},
+	}
+	for _, c := range cases {
+		got, err := New(WithProviderRules(c.provider)).Canonicalize(c.raw)
+		if !errors.Is(err, c.err) || got != c.want {
+			t.Errorf("Canonicalize(%q, provider=%v) = %q, %v; want %q, %v", c.raw, c.provider, got, err, c.want, c.err)
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	records := []Record{
+		{ID: 3, Email: "foo@qq.com"},
+		{ID: 1, Email: "Foo@QQ.com"},
+		{ID: 2, Email: "Bar@qq.com"},
+		{ID: 4, Email: "baz@qq.com"},
+		{ID: 5, Email: "broken"},
+		{ID: 6, Email: "a.b+x@gmail.com"},
+		{ID: 7, Email: "ab@gmail.com"},
+	}
+	report := New().Plan(records)
+	if len(report.Collisions) != 1 || report.Collisions[0].Canonical != "foo@qq.com" ||
+		report.Collisions[0].Records[0].ID != 1 || report.Collisions[0].Records[1].ID != 3 {
+		t.Errorf("unexpected collisions %+v", report.Collisions)
+	}
+	if len(report.Rewrites) != 1 || report.Rewrites[0].ID != 2 || report.Rewrites[0].Canonical != "bar@qq.com" {
+		t.Errorf("unexpected rewrites %+v", report.Rewrites)
+	}
+	if len(report.Invalid) != 1 || report.Invalid[0].ID != 5 {
+		t.Errorf("unexpected invalid %+v", report.Invalid)
+	}
+	// 开启服务商规则后 Gmail 的两种写法冲突
+	report = New(WithProviderRules(true)).Plan(records)
+	if len(report.Collisions) != 2 || report.Collisions[0].Canonical != "ab@gmail.com" {
+		t.Errorf("unexpected collisions with provider rules %+v", report.Collisions)
+	}
+}
@@ -0,0 +1,46 @@
+package localcache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache_Evict(t *testing.T) {
+	c := New[string, int](2, time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	// 访问 a 之后，b 成为最久未使用的元素
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("expected a=1, got %d, %v", v, ok)
+	}
+	c.Set("c", 3)
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("b should have been evicted")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatal("c should be cached")
+	}
+	stats := c.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Size != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCache_Expire(t *testing.T) {
+	c := New[string, int](10, time.Minute)
+	c.SetWithTTL("a", 1, 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("a should have expired")
+	}
+	c.Set("b", 2)
+	c.Delete("b")
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("b should have been deleted")
+	}
+	c.Set("c", 3)
+	c.Purge()
+	if _, ok := c.Get("c"); ok || c.Stats().Size != 0 {
+		t.Fatal("cache should be empty after purge")
+	}
+}
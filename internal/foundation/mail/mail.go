@@ -0,0 +1,26 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrorNoRecipient = errors.New("mail has no recipient")
+
+// 一封待发送的邮件，HTML 与 Text 至少设置一个
+type Message struct {
+	To      []string
+	Subject string
+	HTML    string
+	Text    string
+	// 幂等键，异步发送时相同幂等键的邮件只会发送一次，同步发送时忽略
+	IdempotencyKey string
+	// 异步发送时超过该时间仍未发出则放弃发送，例如已经过期的验证码；零值表示不过期
+	ExpiresAt time.Time
+}
+
+// Mailer 邮件发送的抽象，发送失败时返回错误
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,206 @@
+package mail
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryMailer(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryMailer()
+	if err := m.Send(ctx, Message{Subject: "no recipient"}); !errors.Is(err, ErrorNoRecipient) {
+		t.Fatalf("expected no recipient error, got %v", err)
+	}
+	_ = m.Send(ctx, Message{To: []string{"a@qq.com"}, Subject: "first"})
+	_ = m.Send(ctx, Message{To: []string{"b@qq.com"}, Subject: "second"})
+	_ = m.Send(ctx, Message{To: []string{"a@qq.com"}, Subject: "third"})
+	if msg, ok := m.Last("a@qq.com"); !ok || msg.Subject != "third" {
+		t.Fatalf("unexpected last message %v %v", msg, ok)
+	}
+	m.Err = errors.New("smtp down")
+	if err := m.Send(ctx, Message{To: []string{"a@qq.com"}}); err == nil {
+		t.Fatal("expected injected error")
+	}
+	if len(m.Messages()) != 3 {
+		t.Fatalf("expected failed send not to be recorded, got %d", len(m.Messages()))
+	}
+}
+
+func TestWriterAndFileMailer(t *testing.T) {
+	ctx := context.Background()
+	msg := Message{To: []string{"a@qq.com"}, Subject: "Verification Code", HTML: "<p>123456</p>"}
+	var buf bytes.Buffer
+	if err := NewWriterMailer(&buf).Send(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Subject: Verification Code") || !strings.Contains(buf.String(), "123456") {
+		t.Fatalf("unexpected output %s", buf.String())
+	}
+
+	path := filepath.Join(t.TempDir(), "mail.log")
+	m := NewFileMailer(path)
+	for i := 0; i < 2; i++ {
+		if err := m.Send(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(body), "To: a@qq.com") != 2 {
+		t.Fatalf("expected mails to be appended, got %s", body)
+	}
+}
+
+func TestNewSMTPMailer(t *testing.T) {
+	if _, err := NewSMTPMailer(SMTPConfig{From: "a@qq.com"}); err == nil {
+		t.Fatal("expected missing host to be rejected")
+	}
+	if _, err := NewSMTPMailer(SMTPConfig{Host: "smtp.qq.com", From: "a@qq.com", Security: "ssl3"}); err == nil {
+		t.Fatal("expected unsupported security to be rejected")
+	}
+	m, err := NewSMTPMailer(SMTPConfig{Host: "smtp.qq.com", From: "a@qq.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.c.Port != 587 || m.c.Security != SecurityStartTLS || m.c.Username != "a@qq.com" {
+		t.Fatalf("unexpected defaults %+v", m.c)
+	}
+}
+
+func TestSMTPMailer_Timeout(t *testing.T) {
+	// 接受连接后不返回问候语的服务端
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	addr := ln.Addr().(*net.TCPAddr)
+	m, err := NewSMTPMailer(SMTPConfig{Host: "127.0.0.1", Port: addr.Port, From: "a@qq.com", Security: SecurityNone, Timeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if err = m.Send(context.Background(), Message{To: []string{"b@qq.com"}, Subject: "hi", Text: "hi"}); err == nil {
+		t.Fatal("expected send to time out")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("send took %s, expected to stop after the timeout", elapsed)
+	}
+
+	// ctx 取消时同样中断
+	m.c.Timeout = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	if err = m.Send(ctx, Message{To: []string{"b@qq.com"}, Subject: "hi", Text: "hi"}); err == nil {
+		t.Fatal("expected send to be cancelled")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("send took %s, expected to stop when ctx is done", elapsed)
+	}
+}
+
+// plainSMTPServer 不支持 STARTTLS 与 AUTH 的 SMTP 服务端，返回收到的邮件数据
+func plainSMTPServer(t *testing.T) (int, <-chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	received := make(chan string, 4)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				fmt.Fprint(conn, "220 test\r\n")
+				var data strings.Builder
+				inData := false
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if inData {
+						if line == ".\r\n" {
+							inData = false
+							received <- data.String()
+							fmt.Fprint(conn, "250 ok\r\n")
+						} else {
+							data.WriteString(line)
+						}
+						continue
+					}
+					switch cmd := strings.ToUpper(strings.Fields(line)[0]); cmd {
+					case "EHLO":
+						fmt.Fprint(conn, "250-test\r\n250 8BITMIME\r\n")
+					case "DATA":
+						inData = true
+						fmt.Fprint(conn, "354 go\r\n")
+					case "QUIT":
+						fmt.Fprint(conn, "221 bye\r\n")
+						return
+					default:
+						fmt.Fprint(conn, "250 ok\r\n")
+					}
+				}
+			}(conn)
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSMTPMailer_Downgrade(t *testing.T) {
+	port, received := plainSMTPServer(t)
+	msg := Message{To: []string{"b@qq.com"}, Subject: "hi", Text: "code 123456"}
+	send := func(security, password string) error {
+		m, err := NewSMTPMailer(SMTPConfig{Host: "127.0.0.1", Port: port, From: "a@qq.com", Security: security, Password: password})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m.Send(context.Background(), msg)
+	}
+	// starttls 模式下服务端不支持 STARTTLS 时拒绝以明文发送
+	if err := send(SecurityStartTLS, ""); !errors.Is(err, ErrorStartTLSUnsupported) {
+		t.Fatalf("expected ErrorStartTLSUnsupported, got %v", err)
+	}
+	// 配置了密码但服务端不支持 AUTH 时拒绝未认证发送
+	if err := send(SecurityNone, "secret"); !errors.Is(err, ErrorAuthUnsupported) {
+		t.Fatalf("expected ErrorAuthUnsupported, got %v", err)
+	}
+	if err := send(SecurityNone, ""); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case data := <-received:
+		if !strings.Contains(data, "code 123456") {
+			t.Fatalf("unexpected mail data %q", data)
+		}
+	default:
+		t.Fatal("expected mail to be sent in none mode")
+	}
+}
@@ -0,0 +1,58 @@
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryMailer 只在内存中记录发送的邮件，用于测试断言邮件内容
+type MemoryMailer struct {
+	mu   sync.Mutex
+	msgs []Message
+	// 不为空时 Send 直接返回该错误，用于模拟发送失败
+	Err error
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrorNoRecipient
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Err != nil {
+		return m.Err
+	}
+	m.msgs = append(m.msgs, msg)
+	return nil
+}
+
+// Messages 返回已发送邮件的副本
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.msgs...)
+}
+
+// Last 返回最近一封发送给 to 的邮件
+func (m *MemoryMailer) Last(to string) (Message, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.msgs) - 1; i >= 0; i-- {
+		for _, addr := range m.msgs[i].To {
+			if addr == to {
+				return m.msgs[i], true
+			}
+		}
+	}
+	return Message{}, false
+}
+
+func (m *MemoryMailer) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.msgs = nil
+}
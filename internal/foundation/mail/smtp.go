@@ -0,0 +1,177 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	email2 "github.com/jordan-wright/email"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+const (
+	// 连接建立后通过 STARTTLS 升级为加密连接
+	SecurityStartTLS = "starttls"
+	// 直接建立 TLS 连接，一般使用 465 端口
+	SecurityTLS = "tls"
+	// 服务端支持时仍会尝试 STARTTLS，不支持时使用明文连接
+	SecurityNone = "none"
+
+	// DefaultSMTPTimeout ctx 没有截止时间时，一次发送从建立连接到结束的最长时间
+	DefaultSMTPTimeout = 30 * time.Second
+)
+
+var (
+	ErrorStartTLSUnsupported = errors.New("smtp server does not support STARTTLS")
+	ErrorAuthUnsupported     = errors.New("smtp server does not support AUTH")
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Security string
+	// 连接与读写的超时时间，ctx 的截止时间更早时以 ctx 为准
+	Timeout time.Duration
+}
+
+type SMTPMailer struct {
+	c SMTPConfig
+}
+
+func NewSMTPMailer(c SMTPConfig) (*SMTPMailer, error) {
+	if c.Host == "" || c.From == "" {
+		return nil, errors.New("smtp host and from address are required")
+	}
+	if c.Port <= 0 {
+		c.Port = 587
+	}
+	if c.Username == "" {
+		c.Username = c.From
+	}
+	switch c.Security {
+	case "":
+		c.Security = SecurityStartTLS
+	case SecurityStartTLS, SecurityTLS, SecurityNone:
+	default:
+		return nil, fmt.Errorf("unsupported smtp security %q", c.Security)
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultSMTPTimeout
+	}
+	return &SMTPMailer{c: c}, nil
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrorNoRecipient
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	em := email2.NewEmail()
+	em.From = m.c.From
+	em.To = msg.To
+	em.Subject = msg.Subject
+	em.HTML = []byte(msg.HTML)
+	em.Text = []byte(msg.Text)
+	addr := net.JoinHostPort(m.c.Host, strconv.Itoa(m.c.Port))
+	if err := m.send(ctx, addr, em); err != nil {
+		return fmt.Errorf("send mail to %v via %s err:%w", msg.To, addr, err)
+	}
+	return nil
+}
+
+// send 自己建立连接而不使用 email 包的发送方法，以便设置连接与读写超时，ctx 取消时中断正在进行的读写
+func (m *SMTPMailer) send(ctx context.Context, addr string, em *email2.Email) error {
+	raw, err := em.Bytes()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.c.Timeout)
+	defer cancel()
+	dialer := &net.Dialer{Timeout: m.c.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+	tlsConfig := &tls.Config{ServerName: m.c.Host}
+	if m.c.Security == SecurityTLS {
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			return err
+		}
+		conn = tlsConn
+	}
+	c, err := smtp.NewClient(conn, m.c.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if err = c.Hello("localhost"); err != nil {
+		return err
+	}
+	// starttls 模式下服务端必须支持 STARTTLS，否则中间人去掉该能力就能让邮件以明文发送；none 模式下尽量加密
+	if m.c.Security != SecurityTLS {
+		ok, _ := c.Extension("STARTTLS")
+		if !ok && m.c.Security == SecurityStartTLS {
+			return ErrorStartTLSUnsupported
+		}
+		if ok {
+			if err = c.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		}
+	}
+	// 配置了密码时必须完成认证，不能静默地以未认证身份发送
+	if m.c.Password != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return ErrorAuthUnsupported
+		}
+		if err = c.Auth(smtp.PlainAuth("", m.c.Username, m.c.Password, m.c.Host)); err != nil {
+			return err
+		}
+	}
+	from, err := netmail.ParseAddress(em.From)
+	if err != nil {
+		return err
+	}
+	if err = c.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, to := range em.To {
+		rcpt, err := netmail.ParseAddress(to)
+		if err != nil {
+			return err
+		}
+		if err = c.Rcpt(rcpt.Address); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(raw); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -0,0 +1,72 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WriterMailer 把邮件以可读的文本写入 io.Writer，用于本地开发时直接在控制台查看邮件
+type WriterMailer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterMailer(w io.Writer) *WriterMailer {
+	return &WriterMailer{w: w}
+}
+
+func (m *WriterMailer) Send(ctx context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrorNoRecipient
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, err := io.WriteString(m.w, format(msg))
+	return err
+}
+
+// FileMailer 把邮件追加写入文件，每次发送时打开文件，便于外部清理或轮转
+type FileMailer struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileMailer(path string) *FileMailer {
+	return &FileMailer{path: path}
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrorNoRecipient
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open mail file %s err:%w", m.path, err)
+	}
+	if _, err = io.WriteString(f, format(msg)); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func format(msg Message) string {
+	var sb strings.Builder
+	sb.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\n")
+	sb.WriteString("To: " + strings.Join(msg.To, ", ") + "\n")
+	sb.WriteString("Subject: " + msg.Subject + "\n\n")
+	if msg.Text != "" {
+		sb.WriteString(msg.Text + "\n")
+	} else {
+		sb.WriteString(msg.HTML + "\n")
+	}
+	sb.WriteString("----\n")
+	return sb.String()
+}
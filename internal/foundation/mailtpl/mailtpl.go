@@ -0,0 +1,201 @@
+package mailtpl
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var embedded embed.FS
+
+const DefaultLocale = "zh-CN"
+
+var ErrorTemplateNotFound = errors.New("mail template not found")
+
+// 邮件模板的目录结构为 <locale>/<kind>.txt 与 <locale>/<kind>.html，
+// txt 文件需要定义 subject 与 body 两个模板，html 文件为可选的 HTML 正文
+type Renderer struct {
+	defaultLocale string
+	text          map[string]*texttemplate.Template
+	html          map[string]*htmltemplate.Template
+}
+
+// Rendered 渲染后的邮件内容
+type Rendered struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// New 加载内置模板，dir 不为空时用该目录下的同名模板覆盖内置模板。
+// txt 与 html 作为一组覆盖：只提供 txt 时不再使用内置的 html，避免两种正文内容不一致；只提供 html 时沿用内置的 txt
+func New(dir, defaultLocale string) (*Renderer, error) {
+	if defaultLocale == "" {
+		defaultLocale = DefaultLocale
+	}
+	r := &Renderer{
+		defaultLocale: defaultLocale,
+		text:          make(map[string]*texttemplate.Template),
+		html:          make(map[string]*htmltemplate.Template),
+	}
+	sub, err := fs.Sub(embedded, "templates")
+	if err != nil {
+		return nil, err
+	}
+	if err = r.load(sub); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		override := &Renderer{
+			text: make(map[string]*texttemplate.Template),
+			html: make(map[string]*htmltemplate.Template),
+		}
+		if err = override.load(os.DirFS(dir)); err != nil {
+			return nil, fmt.Errorf("load mail templates from %s err:%w", dir, err)
+		}
+		for key, t := range override.text {
+			r.text[key] = t
+			delete(r.html, key)
+		}
+		for key, t := range override.html {
+			r.html[key] = t
+		}
+	}
+	return r, nil
+}
+
+func (r *Renderer) load(fsys fs.FS) error {
+	return fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		locale := path.Dir(p)
+		if locale == "." || strings.Contains(locale, "/") {
+			return nil
+		}
+		ext := path.Ext(p)
+		key := templateKey(locale, strings.TrimSuffix(path.Base(p), ext))
+		content, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		switch ext {
+		case ".txt":
+			t, err := texttemplate.New(key).Option("missingkey=error").Parse(string(content))
+			if err != nil {
+				return fmt.Errorf("parse %s err:%w", p, err)
+			}
+			if t.Lookup("subject") == nil || t.Lookup("body") == nil {
+				return fmt.Errorf("%s must define subject and body", p)
+			}
+			r.text[key] = t
+		case ".html":
+			t, err := htmltemplate.New(key).Option("missingkey=error").Parse(string(content))
+			if err != nil {
+				return fmt.Errorf("parse %s err:%w", p, err)
+			}
+			r.html[key] = t
+		}
+		return nil
+	})
+}
+
+// Render 按语言渲染指定类型的邮件，依次尝试 zh-CN、zh 与默认语言
+func (r *Renderer) Render(kind, locale string, data any) (Rendered, error) {
+	var out Rendered
+	for _, l := range r.candidates(kind, locale) {
+		key := templateKey(l, kind)
+		t, ok := r.text[key]
+		if !ok {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := t.ExecuteTemplate(&buf, "subject", data); err != nil {
+			return out, err
+		}
+		out.Subject = strings.TrimSpace(buf.String())
+		buf.Reset()
+		if err := t.ExecuteTemplate(&buf, "body", data); err != nil {
+			return out, err
+		}
+		out.Text = buf.String()
+		if h, ok := r.html[key]; ok {
+			buf.Reset()
+			if err := h.Execute(&buf, data); err != nil {
+				return out, err
+			}
+			out.HTML = buf.String()
+		}
+		return out, nil
+	}
+	return out, fmt.Errorf("%w: %s", ErrorTemplateNotFound, kind)
+}
+
+func (r *Renderer) candidates(kind, locale string) []string {
+	var res []string
+	if locale = Normalize(locale); locale != "" {
+		res = append(res, locale)
+		if i := strings.IndexByte(locale, '-'); i > 0 {
+			res = append(res, locale[:i])
+		} else {
+			res = append(res, r.regions(kind, locale)...)
+		}
+	}
+	return append(res, r.defaultLocale)
+}
+
+// regions 只给了语言时，返回该语言下提供了该类型模板的地区，默认语言排在最前，其余按字母顺序，
+// 保证每次选中的模板相同
+func (r *Renderer) regions(kind, lang string) []string {
+	var res []string
+	for key := range r.text {
+		l, k, _ := strings.Cut(key, "/")
+		if k == kind && strings.HasPrefix(l, lang+"-") {
+			res = append(res, l)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if (res[i] == r.defaultLocale) != (res[j] == r.defaultLocale) {
+			return res[i] == r.defaultLocale
+		}
+		return res[i] < res[j]
+	})
+	return res
+}
+
+// Normalize 规范化语言标签，例如 zh_cn 转为 zh-CN
+func Normalize(locale string) string {
+	locale = strings.TrimSpace(strings.ReplaceAll(locale, "_", "-"))
+	if locale == "" {
+		return ""
+	}
+	parts := strings.SplitN(locale, "-", 2)
+	parts[0] = strings.ToLower(parts[0])
+	if len(parts) == 2 {
+		parts[1] = strings.ToUpper(parts[1])
+	}
+	return strings.Join(parts, "-")
+}
+
+// ParseAcceptLanguage 取出 Accept-Language 中的第一个语言
+func ParseAcceptLanguage(header string) string {
+	first, _, _ := strings.Cut(header, ",")
+	first, _, _ = strings.Cut(first, ";")
+	if first = strings.TrimSpace(first); first == "*" {
+		return ""
+	}
+	return Normalize(first)
+}
+
+func templateKey(locale, kind string) string {
+	return locale + "/" + kind
+}
@@ -0,0 +1,153 @@
+package mailtpl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var codeData = map[string]string{"Code": "123456", "Minutes": "5"}
+
+func TestRenderer_Locale(t *testing.T) {
+	r, err := New("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		locale  string
+		subject string
+	}{
+		{"", "重置密码"},
+		{"en", "Reset your password"},
+		{"en-US", "Reset your password"},
+		{"zh", "重置密码"},
+		{"fr-FR", "重置密码"},
+	}
+	for _, c := range cases {
+		out, err := r.Render("code_reset", c.locale, codeData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out.Subject != c.subject {
+			t.Errorf("locale %q: subject %q, want %q", c.locale, out.Subject, c.subject)
+		}
+		if !strings.Contains(out.Text, "123456") || !strings.Contains(out.HTML, "<strong>123456</strong>") {
+			t.Errorf("locale %q: code missing in %+v", c.locale, out)
+		}
+	}
+}
+
+func TestRenderer_EscapeHTML(t *testing.T) {
+	r, err := New("", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := r.Render("welcome", "", map[string]string{"Email": "<a>@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.HTML, "<a>") || !strings.Contains(out.Text, "<a>") {
+		t.Errorf("unexpected escaping: %+v", out)
+	}
+}
+
+func TestRenderer_SecurityEvent(t *testing.T) {
+	r, err := New("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := r.Render("security_alert", "en", map[string]string{
+		"Email":    "old@example.com",
+		"Time":     "2024-01-01 00:00:00 UTC",
+		"Event":    "email_changed",
+		"NewEmail": "new@example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.Text, "changed to new@example.com") || !strings.Contains(out.HTML, "changed to new@example.com") {
+		t.Errorf("event not rendered: %+v", out)
+	}
+}
+
+func TestRenderer_Override(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "en"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	txt := `{{define "subject"}}Custom{{end}}{{define "body"}}code {{.Code}}{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "en", "code_reset.txt"), []byte(txt), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := New(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := r.Render("code_reset", "en", codeData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Subject != "Custom" || out.Text != "code 123456" {
+		t.Errorf("override not applied: %+v", out)
+	}
+	// 只覆盖 txt 时不再使用内置的 html
+	if out.HTML != "" {
+		t.Errorf("expected embedded html to be dropped, got %q", out.HTML)
+	}
+	if _, err = r.Render("unknown", "en", codeData); !errors.Is(err, ErrorTemplateNotFound) {
+		t.Errorf("expected ErrorTemplateNotFound, got %v", err)
+	}
+}
+
+func TestRenderer_LanguageOnly(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "zh-TW"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	txt := `{{define "subject"}}重設密碼{{end}}{{define "body"}}{{.Code}}{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "zh-TW", "code_reset.txt"), []byte(txt), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// 只给了语言时按地区的字母顺序选择，结果不随 map 的遍历顺序变化
+	r, err := New(dir, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		out, err := r.Render("code_reset", "zh", codeData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out.Subject != "重置密码" {
+			t.Fatalf("expected zh-CN to be chosen, got %q", out.Subject)
+		}
+	}
+	// 默认语言属于该语言时优先使用默认语言
+	r, err = New(dir, "zh-TW")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := r.Render("code_reset", "zh", codeData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Subject != "重設密碼" {
+		t.Fatalf("expected default locale zh-TW to be chosen, got %q", out.Subject)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	cases := map[string]string{
+		"en-us,en;q=0.9": "en-US",
+		"zh_cn":          "zh-CN",
+		"*":              "",
+		"":               "",
+	}
+	for in, want := range cases {
+		if got := ParseAcceptLanguage(in); got != want {
+			t.Errorf("ParseAcceptLanguage(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package password
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrorUnknownAlgorithm = errors.New("unknown password hash algorithm")
+	ErrorMalformedHash    = errors.New("malformed password hash")
+)
+
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// 密码哈希模块的抽象接口定义
+type Hasher interface {
+	// 计算密码哈希，返回值中同时编码了算法与参数
+	Hash(password string) (string, error)
+	// 校验明文密码与已存储的哈希是否匹配
+	Verify(password, encoded string) (bool, error)
+	// 已存储的哈希是否需要按照当前配置重新计算
+	NeedsRehash(encoded string) bool
+}
+
+// 具体的哈希算法实现
+type scheme interface {
+	Hasher
+	// 判断哈希是否由该算法生成
+	Identify(encoded string) bool
+}
+
+// Manager 使用配置的算法生成新哈希，同时能够校验所有已支持算法生成的哈希.
+// 无法识别的存量数据视为历史遗留的明文密码，校验通过后应当立即重新计算哈希
+type Manager struct {
+	current scheme
+	schemes []scheme
+}
+
+func NewManager(algorithm string, opts ...Option) (*Manager, error) {
+	options := NewOptions(opts...)
+	bc := &bcryptScheme{cost: options.BcryptCost}
+	ar := &argon2Scheme{params: options.Argon2}
+	m := &Manager{schemes: []scheme{bc, ar}}
+	switch algorithm {
+	case "", AlgorithmBcrypt:
+		m.current = bc
+	case AlgorithmArgon2id:
+		m.current = ar
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrorUnknownAlgorithm, algorithm)
+	}
+	return m, nil
+}
+
+func (m *Manager) Hash(password string) (string, error) {
+	return m.current.Hash(password)
+}
+
+func (m *Manager) Verify(password, encoded string) (bool, error) {
+	if encoded == "" {
+		return false, nil
+	}
+	if s := m.identify(encoded); s != nil {
+		return s.Verify(password, encoded)
+	}
+	// 历史遗留的明文密码，使用常量时间比较
+	return subtle.ConstantTimeCompare([]byte(password), []byte(encoded)) == 1, nil
+}
+
+func (m *Manager) NeedsRehash(encoded string) bool {
+	s := m.identify(encoded)
+	if s != m.current {
+		return true
+	}
+	return s.NeedsRehash(encoded)
+}
+
+func (m *Manager) identify(encoded string) scheme {
+	for _, s := range m.schemes {
+		if s.Identify(encoded) {
+			return s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package password
+
+import (
+	"strings"
+	"testing"
+)
+
+var testArgon2 = Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestManager_HashAndVerify(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmBcrypt, AlgorithmArgon2id} {
+		m, err := NewManager(algorithm, WithBcryptCost(4), WithArgon2Params(testArgon2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash, err := m.Hash("Passw0rd!")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(hash, "Passw0rd!") {
+			t.Fatalf("%s: hash contains the plaintext", algorithm)
+		}
+		ok, err := m.Verify("Passw0rd!", hash)
+		if err != nil || !ok {
+			t.Fatalf("%s: verify correct password got %v, %v", algorithm, ok, err)
+		}
+		ok, err = m.Verify("passw0rd!", hash)
+		if err != nil || ok {
+			t.Fatalf("%s: verify wrong password got %v, %v", algorithm, ok, err)
+		}
+		if m.NeedsRehash(hash) {
+			t.Fatalf("%s: fresh hash should not need rehash", algorithm)
+		}
+	}
+}
+
+func TestManager_NeedsRehash(t *testing.T) {
+	bc, _ := NewManager(AlgorithmBcrypt, WithBcryptCost(4))
+	ar, _ := NewManager(AlgorithmArgon2id, WithArgon2Params(testArgon2))
+
+	bcHash, _ := bc.Hash("12345678")
+	if !ar.NeedsRehash(bcHash) {
+		t.Fatal("bcrypt hash should be upgraded when argon2id is configured")
+	}
+	ok, err := ar.Verify("12345678", bcHash)
+	if err != nil || !ok {
+		t.Fatalf("argon2id manager should still verify bcrypt hashes, got %v, %v", ok, err)
+	}
+
+	stronger, _ := NewManager(AlgorithmBcrypt, WithBcryptCost(5))
+	if !stronger.NeedsRehash(bcHash) {
+		t.Fatal("bcrypt hash with an old cost should need rehash")
+	}
+}
+
+func TestManager_LegacyPlaintext(t *testing.T) {
+	m, _ := NewManager(AlgorithmBcrypt, WithBcryptCost(4))
+	ok, err := m.Verify("12345678", "12345678")
+	if err != nil || !ok {
+		t.Fatalf("legacy plaintext should verify, got %v, %v", ok, err)
+	}
+	if ok, _ = m.Verify("12345679", "12345678"); ok {
+		t.Fatal("legacy plaintext should not verify a different password")
+	}
+	if !m.NeedsRehash("12345678") {
+		t.Fatal("legacy plaintext should need rehash")
+	}
+	if ok, _ = m.Verify("", ""); ok {
+		t.Fatal("empty stored password should never verify")
+	}
+}
+
+func TestManager_UnknownAlgorithm(t *testing.T) {
+	if _, err := NewManager("md5"); err == nil {
+		t.Fatal("expected error for unknown algorithm")
+	}
+}
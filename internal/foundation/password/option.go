@@ -0,0 +1,72 @@
+package password
+
+import "golang.org/x/crypto/bcrypt"
+
+type Options struct {
+	// bcrypt 计算强度
+	BcryptCost int
+	// argon2id 参数
+	Argon2 Argon2Params
+}
+
+type Option func(*Options)
+
+const (
+	DefaultBcryptCost        = bcrypt.DefaultCost
+	DefaultArgon2Memory      = 64 * 1024
+	DefaultArgon2Iterations  = 3
+	DefaultArgon2Parallelism = 2
+	DefaultArgon2SaltLength  = 16
+	DefaultArgon2KeyLength   = 32
+)
+
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		BcryptCost: DefaultBcryptCost,
+		Argon2: Argon2Params{
+			Memory:      DefaultArgon2Memory,
+			Iterations:  DefaultArgon2Iterations,
+			Parallelism: DefaultArgon2Parallelism,
+			SaltLength:  DefaultArgon2SaltLength,
+			KeyLength:   DefaultArgon2KeyLength,
+		},
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	repair(options)
+	return options
+}
+
+func WithBcryptCost(cost int) Option {
+	return func(o *Options) {
+		o.BcryptCost = cost
+	}
+}
+
+func WithArgon2Params(params Argon2Params) Option {
+	return func(o *Options) {
+		o.Argon2 = params
+	}
+}
+
+func repair(o *Options) {
+	if o.BcryptCost < bcrypt.MinCost || o.BcryptCost > bcrypt.MaxCost {
+		o.BcryptCost = DefaultBcryptCost
+	}
+	if o.Argon2.Memory == 0 {
+		o.Argon2.Memory = DefaultArgon2Memory
+	}
+	if o.Argon2.Iterations == 0 {
+		o.Argon2.Iterations = DefaultArgon2Iterations
+	}
+	if o.Argon2.Parallelism == 0 {
+		o.Argon2.Parallelism = DefaultArgon2Parallelism
+	}
+	if o.Argon2.SaltLength == 0 {
+		o.Argon2.SaltLength = DefaultArgon2SaltLength
+	}
+	if o.Argon2.KeyLength == 0 {
+		o.Argon2.KeyLength = DefaultArgon2KeyLength
+	}
+}
@@ -0,0 +1,243 @@
+package password
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 内置的常见弱密码列表
+//
+//go:embed common_passwords.txt
+var commonPasswords []byte
+
+const (
+	ViolationTooShort          = "too_short"
+	ViolationTooLong           = "too_long"
+	ViolationMissingUpper      = "missing_upper"
+	ViolationMissingLower      = "missing_lower"
+	ViolationMissingDigit      = "missing_digit"
+	ViolationMissingSymbol     = "missing_symbol"
+	ViolationRepeatedChars     = "repeated_chars"
+	ViolationSequentialChars   = "sequential_chars"
+	ViolationForbiddenSequence = "forbidden_sequence"
+	ViolationCommonPassword    = "common_password"
+	ViolationSimilarToEmail    = "similar_to_email"
+)
+
+// 密码不满足的某条规则
+type Violation struct {
+	Code    string
+	Message string
+}
+
+type PolicyConfig struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// 禁止出现的子串，不区分大小写
+	ForbiddenSequences []string
+	// 同一字符最多连续出现的次数
+	MaxRepeat int
+	// 连续递增或递减字符（如 abcd、4321）的最大长度
+	MaxSequence int
+	// 额外的弱密码列表文件，每行一个密码
+	CommonPasswordsFile string
+	// 是否允许密码与邮箱相似
+	AllowEmailSimilar bool
+	// 密码的最大字节数，0 表示不限制。使用 bcrypt 时应设为 BcryptMaxBytes
+	MaxBytes int
+}
+
+const (
+	DefaultMinLength   = 8
+	DefaultMaxLength   = 64
+	DefaultMaxRepeat   = 3
+	DefaultMaxSequence = 4
+
+	// bcrypt 最多只处理 72 字节，多字节字符的密码按字符数可能没有超出 MaxLength
+	BcryptMaxBytes = 72
+)
+
+// Policy 校验密码是否满足配置的强度要求
+type Policy struct {
+	c         PolicyConfig
+	forbidden []string
+	common    map[string]struct{}
+}
+
+func NewPolicy(c PolicyConfig) (*Policy, error) {
+	if c.MinLength <= 0 {
+		c.MinLength = DefaultMinLength
+	}
+	if c.MaxLength <= 0 {
+		c.MaxLength = DefaultMaxLength
+	}
+	if c.MaxRepeat <= 0 {
+		c.MaxRepeat = DefaultMaxRepeat
+	}
+	if c.MaxSequence <= 0 {
+		c.MaxSequence = DefaultMaxSequence
+	}
+	p := &Policy{c: c, common: make(map[string]struct{})}
+	for _, seq := range c.ForbiddenSequences {
+		if seq != "" {
+			p.forbidden = append(p.forbidden, strings.ToLower(seq))
+		}
+	}
+	p.loadCommon(commonPasswords)
+	if c.CommonPasswordsFile != "" {
+		body, err := os.ReadFile(c.CommonPasswordsFile)
+		if err != nil {
+			return nil, fmt.Errorf("load common passwords err:%w", err)
+		}
+		p.loadCommon(body)
+	}
+	return p, nil
+}
+
+// Check 返回密码不满足的全部规则，返回空表示密码可用
+func (p *Policy) Check(password, email string) []Violation {
+	var violations []Violation
+	add := func(code, format string, args ...interface{}) {
+		violations = append(violations, Violation{Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+	length := utf8.RuneCountInString(password)
+	if length < p.c.MinLength {
+		add(ViolationTooShort, "password must be at least %d characters", p.c.MinLength)
+	}
+	if length > p.c.MaxLength {
+		add(ViolationTooLong, "password must be at most %d characters", p.c.MaxLength)
+	} else if p.c.MaxBytes > 0 && len(password) > p.c.MaxBytes {
+		add(ViolationTooLong, "password must be at most %d bytes", p.c.MaxBytes)
+	}
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if p.c.RequireUpper && !upper {
+		add(ViolationMissingUpper, "password must contain an uppercase letter")
+	}
+	if p.c.RequireLower && !lower {
+		add(ViolationMissingLower, "password must contain a lowercase letter")
+	}
+	if p.c.RequireDigit && !digit {
+		add(ViolationMissingDigit, "password must contain a digit")
+	}
+	if p.c.RequireSymbol && !symbol {
+		add(ViolationMissingSymbol, "password must contain a symbol")
+	}
+	repeat, sequence := runs(password)
+	if repeat > p.c.MaxRepeat {
+		add(ViolationRepeatedChars, "password must not repeat a character more than %d times in a row", p.c.MaxRepeat)
+	}
+	if sequence > p.c.MaxSequence {
+		add(ViolationSequentialChars, "password must not contain more than %d sequential characters", p.c.MaxSequence)
+	}
+	lowered := strings.ToLower(password)
+	for _, seq := range p.forbidden {
+		if strings.Contains(lowered, seq) {
+			add(ViolationForbiddenSequence, "password must not contain %q", seq)
+			break
+		}
+	}
+	if _, ok := p.common[lowered]; ok {
+		add(ViolationCommonPassword, "password is too common")
+	}
+	if !p.c.AllowEmailSimilar && similarToEmail(lowered, email) {
+		add(ViolationSimilarToEmail, "password must not be similar to the email")
+	}
+	return violations
+}
+
+func (p *Policy) loadCommon(body []byte) {
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if line != "" {
+			p.common[line] = struct{}{}
+		}
+	}
+}
+
+// runs 返回同一字符连续出现的最大次数，以及连续递增或递减字符的最大长度
+func runs(password string) (int, int) {
+	var prev rune
+	var repeat, sequence, step int
+	maxRepeat, maxSequence := 0, 0
+	for i, r := range []rune(password) {
+		if i == 0 {
+			repeat, sequence = 1, 1
+		} else {
+			if r == prev {
+				repeat++
+			} else {
+				repeat = 1
+			}
+			switch d := int(r - prev); {
+			case (d == 1 || d == -1) && d == step:
+				sequence++
+			case d == 1 || d == -1:
+				step = d
+				sequence = 2
+			default:
+				step = 0
+				sequence = 1
+			}
+		}
+		prev = r
+		maxRepeat = max(maxRepeat, repeat)
+		maxSequence = max(maxSequence, sequence)
+	}
+	return maxRepeat, maxSequence
+}
+
+// similarToEmail 判断密码是否包含邮箱用户名，或与邮箱用户名只有细微差别
+func similarToEmail(lowered, email string) bool {
+	local, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	if len(local) < 3 {
+		return false
+	}
+	if strings.Contains(lowered, local) || strings.Contains(local, lowered) {
+		return true
+	}
+	return levenshtein(lowered, local) <= 2
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
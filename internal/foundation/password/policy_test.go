@@ -0,0 +1,75 @@
+package password
+
+import (
+	"strings"
+	"testing"
+)
+
+func codes(violations []Violation) map[string]bool {
+	m := make(map[string]bool)
+	for _, v := range violations {
+		m[v.Code] = true
+	}
+	return m
+}
+
+func TestPolicy_Check(t *testing.T) {
+	p, err := NewPolicy(PolicyConfig{
+		RequireDigit:       true,
+		RequireLower:       true,
+		ForbiddenSequences: []string{"tiktok"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		password string
+		email    string
+		expect   string
+	}{
+		{"short1", "a@qq.com", ViolationTooShort},
+		{"aaaaaaaa", "a@qq.com", ViolationRepeatedChars},
+		{"aaaaaaaa", "a@qq.com", ViolationMissingDigit},
+		{"xyabcdef9", "a@qq.com", ViolationSequentialChars},
+		{"my-TikTok-9", "a@qq.com", ViolationForbiddenSequence},
+		{"password1", "a@qq.com", ViolationCommonPassword},
+		{"zhangsan88!", "zhangsan@qq.com", ViolationSimilarToEmail},
+		{"zhangsen", "zhangsan@qq.com", ViolationSimilarToEmail},
+	}
+	for _, c := range cases {
+		if got := codes(p.Check(c.password, c.email)); !got[c.expect] {
+			t.Errorf("%q: expected %s, got %v", c.password, c.expect, got)
+		}
+	}
+
+	// 长密码短语与符号都应当被接受
+	for _, ok := range []string{"correct horse battery staple 7", "Tr0ub4dor&3", "花开-2024-spring"} {
+		if v := p.Check(ok, "someone@qq.com"); len(v) != 0 {
+			t.Errorf("%q: expected no violation, got %v", ok, v)
+		}
+	}
+}
+
+func TestPolicy_MaxBytes(t *testing.T) {
+	p, err := NewPolicy(PolicyConfig{MaxBytes: BcryptMaxBytes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 30 个汉字只有 30 个字符，但 UTF-8 编码后是 90 字节，超出 bcrypt 的限制
+	long := strings.Repeat("密码安全很重要吗", 4)[:90]
+	if got := codes(p.Check(long, "a@qq.com")); !got[ViolationTooLong] {
+		t.Fatalf("expected %d-byte password to be rejected, got %v", len(long), got)
+	}
+	m, err := NewManager(AlgorithmBcrypt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 通过校验的密码一定能被 bcrypt 处理
+	ok := strings.Repeat("密码安全很重要吗", 3)
+	if v := p.Check(ok, "a@qq.com"); len(v) != 0 {
+		t.Fatalf("expected %d-byte password to be accepted, got %v", len(ok), v)
+	}
+	if _, err = m.Hash(ok); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,113 @@
+package publicid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+// 对外的 ID 先用以密钥为参数的 Feistel 网络把 64 位数字 ID 置换为另一个 64 位数字，再编码为定长的 base62 字符串。
+// 置换是一一对应的，连续的数字 ID 置换后看不出先后与数量，没有密钥也无法构造出合法的 ID
+const (
+	Length = 11
+	rounds = 8
+
+	MinSecretLength = 16
+	alphabet        = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+var (
+	ErrorInvalidID   = errors.New("public id is invalid")
+	ErrorShortSecret = errors.New("public id secret must be at least 16 bytes")
+)
+
+var decodeMap = func() [256]int8 {
+	var m [256]int8
+	for i := range m {
+		m[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		m[alphabet[i]] = int8(i)
+	}
+	return m
+}()
+
+// Codec 数字 ID 与对外 ID 互相转换，并发安全。修改密钥后之前发出的对外 ID 全部失效
+type Codec struct {
+	secret []byte
+}
+
+func New(secret []byte) (*Codec, error) {
+	if len(secret) < MinSecretLength {
+		return nil, ErrorShortSecret
+	}
+	return &Codec{secret: append([]byte(nil), secret...)}, nil
+}
+
+func (c *Codec) Encode(id uint64) string {
+	return encode(c.permute(id))
+}
+
+// Decode 只接受 Encode 输出的形式，长度或字符不合法时返回 ErrorInvalidID
+func (c *Codec) Decode(publicID string) (uint64, error) {
+	v, err := decode(publicID)
+	if err != nil {
+		return 0, err
+	}
+	return c.unpermute(v), nil
+}
+
+func (c *Codec) permute(v uint64) uint64 {
+	l, r := uint32(v>>32), uint32(v)
+	for i := 0; i < rounds; i++ {
+		l, r = r, l^c.round(i, r)
+	}
+	return uint64(l)<<32 | uint64(r)
+}
+
+func (c *Codec) unpermute(v uint64) uint64 {
+	l, r := uint32(v>>32), uint32(v)
+	for i := rounds - 1; i >= 0; i-- {
+		l, r = r^c.round(i, l), l
+	}
+	return uint64(l)<<32 | uint64(r)
+}
+
+func (c *Codec) round(i int, half uint32) uint32 {
+	var msg [5]byte
+	msg[0] = byte(i)
+	binary.BigEndian.PutUint32(msg[1:], half)
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(msg[:])
+	return binary.BigEndian.Uint32(mac.Sum(nil))
+}
+
+func encode(v uint64) string {
+	var buf [Length]byte
+	for i := Length - 1; i >= 0; i-- {
+		buf[i] = alphabet[v%62]
+		v /= 62
+	}
+	return string(buf[:])
+}
+
+func decode(s string) (uint64, error) {
+	if len(s) != Length {
+		return 0, ErrorInvalidID
+	}
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		d := decodeMap[s[i]]
+		if d < 0 {
+			return 0, ErrorInvalidID
+		}
+		// 62^11 大于 2^64，超出 uint64 的字符串不是 Encode 的输出
+		hi := v * 62
+		if hi/62 != v || hi+uint64(d) < hi {
+			return 0, ErrorInvalidID
+		}
+		v = hi + uint64(d)
+	}
+	return v, nil
+}
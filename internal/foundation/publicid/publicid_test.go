@@ -0,0 +1,59 @@
+package publicid
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+var secret = []byte("0123456789abcdef")
+
+func TestCodec_RoundTrip(t *testing.T) {
+	c, err := New(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []uint64{0, 1, 2, 1000, 1 << 32, math.MaxUint64 - 1, math.MaxUint64}
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		s := c.Encode(id)
+		if len(s) != Length {
+			t.Fatalf("Encode(%d) = %q, want length %d", id, s, Length)
+		}
+		if seen[s] {
+			t.Fatalf("Encode(%d) = %q collides", id, s)
+		}
+		seen[s] = true
+		got, err := c.Decode(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != id {
+			t.Fatalf("Decode(Encode(%d)) = %d", id, got)
+		}
+	}
+}
+
+func TestCodec_Keyed(t *testing.T) {
+	a, _ := New(secret)
+	b, _ := New([]byte("fedcba9876543210"))
+	if a.Encode(1) == b.Encode(1) {
+		t.Fatal("different secrets produced the same public id")
+	}
+	// 连续的 ID 编码后不应有公共前缀
+	if a.Encode(1)[:4] == a.Encode(2)[:4] {
+		t.Fatalf("sequential ids look alike: %s %s", a.Encode(1), a.Encode(2))
+	}
+}
+
+func TestCodec_DecodeInvalid(t *testing.T) {
+	c, _ := New(secret)
+	for _, s := range []string{"", "abc", "0000000000-", "zzzzzzzzzzz", c.Encode(1) + "0"} {
+		if _, err := c.Decode(s); !errors.Is(err, ErrorInvalidID) {
+			t.Errorf("Decode(%q) err = %v, want ErrorInvalidID", s, err)
+		}
+	}
+	if _, err := New([]byte("short")); !errors.Is(err, ErrorShortSecret) {
+		t.Errorf("expected ErrorShortSecret, got %v", err)
+	}
+}
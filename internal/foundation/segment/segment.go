@@ -0,0 +1,226 @@
+package segment
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// 默认在当前号段用掉 10% 时开始加载下一段，两个号段都在内存中，存储短暂不可用时仍可继续发号
+const (
+	DefaultPreloadRatio  = 0.1
+	DefaultLoadTimeout   = 3 * time.Second
+	DefaultRetryInterval = time.Second
+)
+
+var ErrorEmptyRange = errors.New("loaded id range is empty")
+
+// Range 从存储中预留的一段 ID，范围为 [Start, End)
+type Range struct {
+	Start uint64
+	End   uint64
+}
+
+func (r Range) Size() uint64 {
+	return r.End - r.Start
+}
+
+// Loader 从存储中预留下一段 ID，多次调用返回的号段不能重叠，且后一段大于前一段
+type Loader func(ctx context.Context) (Range, error)
+
+type Options struct {
+	// 当前号段已使用的比例超过该值时在后台加载下一段
+	PreloadRatio float64
+	// 每次加载的超时时间
+	LoadTimeout time.Duration
+	// 后台加载失败后，等待该时长再重试
+	RetryInterval time.Duration
+	// 后台加载失败时回调，用于记录日志
+	OnError func(error)
+	// 获取当前时间，测试时替换
+	Now func() time.Time
+}
+
+type Option func(*Options)
+
+func WithPreloadRatio(ratio float64) Option {
+	return func(o *Options) {
+		if ratio > 0 && ratio < 1 {
+			o.PreloadRatio = ratio
+		}
+	}
+}
+
+func WithLoadTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		if d > 0 {
+			o.LoadTimeout = d
+		}
+	}
+}
+
+func WithRetryInterval(d time.Duration) Option {
+	return func(o *Options) {
+		if d > 0 {
+			o.RetryInterval = d
+		}
+	}
+}
+
+func WithErrorHandler(fn func(error)) Option {
+	return func(o *Options) {
+		o.OnError = fn
+	}
+}
+
+func WithClock(now func() time.Time) Option {
+	return func(o *Options) {
+		o.Now = now
+	}
+}
+
+// Allocator 双缓冲的号段发号器，并发安全。
+// 当前号段用到一定比例时在后台预留下一段，当前号段用完后切换到下一段，
+// 只有两段都用完时才会同步等待加载
+type Allocator struct {
+	load Loader
+	opt  Options
+
+	mu  sync.Mutex
+	cur Range
+	// 下一个要发出的 ID
+	pos  uint64
+	next *Range
+	// 正在进行的加载完成时关闭，没有加载时为 nil
+	loading  chan struct{}
+	lastErr  error
+	failedAt time.Time
+}
+
+func New(load Loader, opts ...Option) *Allocator {
+	opt := Options{
+		PreloadRatio:  DefaultPreloadRatio,
+		LoadTimeout:   DefaultLoadTimeout,
+		RetryInterval: DefaultRetryInterval,
+		Now:           time.Now,
+	}
+	for _, o := range opts {
+		o(&opt)
+	}
+	return &Allocator{load: load, opt: opt}
+}
+
+// Next 返回下一个 ID，两个号段都用完且加载失败时返回加载的错误
+func (a *Allocator) Next(ctx context.Context) (uint64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.fill(ctx); err != nil {
+		return 0, err
+	}
+	id := a.pos
+	a.pos++
+	a.preload()
+	return id, nil
+}
+
+// Warm 当前号段为空时同步加载，用于启动时提前暴露存储的问题
+func (a *Allocator) Warm(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.fill(ctx)
+}
+
+// Remaining 返回内存中还未发出的 ID 数量
+func (a *Allocator) Remaining() uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	n := a.cur.End - a.pos
+	if a.next != nil {
+		n += a.next.Size()
+	}
+	return n
+}
+
+// fill 保证当前号段还有可用的 ID，调用时需要持有锁
+func (a *Allocator) fill(ctx context.Context) error {
+	for a.pos >= a.cur.End {
+		if a.next != nil {
+			a.use(*a.next)
+			a.next = nil
+			continue
+		}
+		done := a.loading
+		if done == nil {
+			done = a.startLoad()
+		}
+		a.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			a.mu.Lock()
+			return ctx.Err()
+		}
+		a.mu.Lock()
+		// 加载成功但号段已被其他调用方用完时继续加载
+		if a.lastErr != nil && a.pos >= a.cur.End && a.next == nil {
+			return a.lastErr
+		}
+	}
+	return nil
+}
+
+// preload 当前号段使用比例超过阈值且没有下一段时在后台加载，失败后按间隔重试，调用时需要持有锁
+func (a *Allocator) preload() {
+	if a.next != nil || a.loading != nil {
+		return
+	}
+	used := a.pos - a.cur.Start
+	if float64(used) < float64(a.cur.Size())*a.opt.PreloadRatio {
+		return
+	}
+	if !a.failedAt.IsZero() && a.opt.Now().Sub(a.failedAt) < a.opt.RetryInterval {
+		return
+	}
+	a.startLoad()
+}
+
+// startLoad 在后台加载一个号段，调用时需要持有锁
+func (a *Allocator) startLoad() chan struct{} {
+	done := make(chan struct{})
+	a.loading = done
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), a.opt.LoadTimeout)
+		r, err := a.load(ctx)
+		cancel()
+		if err == nil && r.End <= r.Start {
+			err = ErrorEmptyRange
+		}
+
+		a.mu.Lock()
+		a.loading = nil
+		a.lastErr = err
+		if err != nil {
+			a.failedAt = a.opt.Now()
+		} else {
+			a.failedAt = time.Time{}
+			if a.pos >= a.cur.End {
+				a.use(r)
+			} else {
+				a.next = &r
+			}
+		}
+		close(done)
+		a.mu.Unlock()
+
+		if err != nil && a.opt.OnError != nil {
+			a.opt.OnError(err)
+		}
+	}()
+	return done
+}
+
+func (a *Allocator) use(r Range) {
+	a.cur = r
+	a.pos = r.Start
+}
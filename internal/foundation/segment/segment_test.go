@@ -0,0 +1,175 @@
+package segment
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeStore 模拟数据库中的 max_id，每次加载预留 step 个 ID
+type fakeStore struct {
+	mu    sync.Mutex
+	max   uint64
+	step  uint64
+	err   error
+	loads int
+}
+
+func (f *fakeStore) load(ctx context.Context) (Range, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.loads++
+	if f.err != nil {
+		return Range{}, f.err
+	}
+	r := Range{Start: f.max + 1, End: f.max + f.step + 1}
+	f.max += f.step
+	return r, nil
+}
+
+func (f *fakeStore) setErr(err error) {
+	f.mu.Lock()
+	f.err = err
+	f.mu.Unlock()
+}
+
+func TestAllocator_Monotonic(t *testing.T) {
+	s := &fakeStore{step: 10}
+	a := New(s.load)
+	ctx := context.Background()
+	for want := uint64(1); want <= 100; want++ {
+		id, err := a.Next(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != want {
+			t.Fatalf("got id %d, want %d", id, want)
+		}
+	}
+}
+
+func TestAllocator_ServeDuringOutage(t *testing.T) {
+	s := &fakeStore{step: 100}
+	a := New(s.load, WithPreloadRatio(0.5))
+	ctx := context.Background()
+	if err := a.Warm(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 60; i++ {
+		if _, err := a.Next(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 等待后台加载下一段
+	deadline := time.Now().Add(time.Second)
+	for a.Remaining() != 140 {
+		if time.Now().After(deadline) {
+			t.Fatalf("next segment not preloaded, remaining %d", a.Remaining())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	outage := errors.New("db down")
+	s.setErr(outage)
+	for i := 0; i < 140; i++ {
+		if _, err := a.Next(ctx); err != nil {
+			t.Fatalf("id %d: %v", i, err)
+		}
+	}
+	if _, err := a.Next(ctx); !errors.Is(err, outage) {
+		t.Fatalf("expected outage error, got %v", err)
+	}
+
+	s.setErr(nil)
+	id, err := a.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id <= 200 {
+		t.Fatalf("id %d reused a reserved range", id)
+	}
+}
+
+func TestAllocator_RetryInterval(t *testing.T) {
+	s := &fakeStore{step: 10}
+	now := time.Unix(0, 0)
+	var mu sync.Mutex
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	a := New(s.load, WithClock(clock), WithRetryInterval(time.Second))
+	ctx := context.Background()
+	if err := a.Warm(ctx); err != nil {
+		t.Fatal(err)
+	}
+	s.setErr(errors.New("db down"))
+	waitLoads := func(n int) {
+		deadline := time.Now().Add(time.Second)
+		for {
+			s.mu.Lock()
+			loads := s.loads
+			s.mu.Unlock()
+			a.mu.Lock()
+			idle := a.loading == nil
+			a.mu.Unlock()
+			if loads == n && idle {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("got %d loads, want %d", loads, n)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := a.Next(ctx); err != nil {
+			t.Fatal(err)
+		}
+		waitLoads(2)
+	}
+	mu.Lock()
+	now = now.Add(time.Second)
+	mu.Unlock()
+	if _, err := a.Next(ctx); err != nil {
+		t.Fatal(err)
+	}
+	waitLoads(3)
+}
+
+func TestAllocator_Concurrent(t *testing.T) {
+	s := &fakeStore{step: 7}
+	a := New(s.load)
+	ctx := context.Background()
+	var (
+		mu   sync.Mutex
+		seen = make(map[uint64]bool)
+		wg   sync.WaitGroup
+	)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				id, err := a.Next(ctx)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				mu.Lock()
+				if seen[id] {
+					t.Errorf("duplicate id %d", id)
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != 4000 {
+		t.Fatalf("got %d ids, want 4000", len(seen))
+	}
+}
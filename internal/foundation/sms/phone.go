@@ -0,0 +1,61 @@
+package sms
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrorInvalidPhone = errors.New("phone number is invalid")
+
+// E.164 号码最多 15 位数字，国家码不以 0 开头
+const (
+	minDigits = 7
+	maxDigits = 15
+)
+
+// NormalizeE164 把手机号规范化为 E.164 格式，例如 +8613800138000。
+// 允许空格、横线、点与括号作为分隔符，00 开头视为国际冠码；
+// 没有国家码的号码使用 defaultCountryCode，并去掉国内长途前缀 0
+func NormalizeE164(raw, defaultCountryCode string) (string, error) {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(raw) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", ErrorInvalidPhone
+		}
+	}
+	number := b.String()
+	switch {
+	case strings.HasPrefix(number, "+"):
+	case strings.HasPrefix(number, "00"):
+		number = "+" + number[2:]
+	default:
+		cc := strings.TrimPrefix(defaultCountryCode, "+")
+		if cc == "" {
+			return "", ErrorInvalidPhone
+		}
+		number = "+" + cc + strings.TrimLeft(number, "0")
+	}
+	if !ValidE164(number) {
+		return "", ErrorInvalidPhone
+	}
+	return number, nil
+}
+
+// ValidE164 判断号码是否为合法的 E.164 格式
+func ValidE164(number string) bool {
+	if len(number) < minDigits+1 || len(number) > maxDigits+1 || number[0] != '+' || number[1] == '0' {
+		return false
+	}
+	for _, r := range number[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
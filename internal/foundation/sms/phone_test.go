@@ -0,0 +1,47 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNormalizeE164(t *testing.T) {
+	cases := []struct {
+		raw  string
+		cc   string
+		want string
+		err  error
+	}{
+		{"+86 138-0013-8000", "", "+8613800138000", nil},
+		{"0086 13800138000", "1", "+8613800138000", nil},
+		{"13800138000", "86", "+8613800138000", nil},
+		{"(020) 1234.5678", "+86", "+862012345678", nil},
+		{"202-555-0143", "1", "+12025550143", nil},
+		{"13800138000", "", "", ErrorInvalidPhone},
+		{"+0123456789", "", "", ErrorInvalidPhone},
+		{"+86 138 0013 800a", "", "", ErrorInvalidPhone},
+		{"+1234567890123456", "", "", ErrorInvalidPhone},
+		{"1+3800138000", "86", "", ErrorInvalidPhone},
+		{"+12345", "", "", ErrorInvalidPhone},
+	}
+	for _, c := range cases {
+		got, err := NormalizeE164(c.raw, c.cc)
+		if !errors.Is(err, c.err) || got != c.want {
+			t.Errorf("NormalizeE164(%q, %q) = %q, %v; want %q, %v", c.raw, c.cc, got, err, c.want, c.err)
+		}
+	}
+}
+
+func TestMemorySender(t *testing.T) {
+	s := NewMemorySender()
+	if err := s.Send(context.Background(), "", "hi"); !errors.Is(err, ErrorNoRecipient) {
+		t.Fatalf("expected ErrorNoRecipient, got %v", err)
+	}
+	if err := s.Send(context.Background(), "+8613800138000", "code 123456"); err != nil {
+		t.Fatal(err)
+	}
+	if msg, ok := s.Last("+8613800138000"); !ok || msg.Text != "code 123456" {
+		t.Errorf("unexpected last message %+v", msg)
+	}
+}
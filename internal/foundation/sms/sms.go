@@ -0,0 +1,75 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"sync"
+)
+
+var ErrorNoRecipient = errors.New("sms has no recipient")
+
+// Sender 短信发送的抽象，to 为 E.164 格式的手机号
+type Sender interface {
+	Send(ctx context.Context, to, text string) error
+}
+
+// LogSender 只把短信内容写入日志，用于本地开发或尚未接入短信服务商的环境
+type LogSender struct {
+	h *log.Helper
+}
+
+func NewLogSender(logger log.Logger) *LogSender {
+	return &LogSender{h: log.NewHelper(logger)}
+}
+
+func (s *LogSender) Send(ctx context.Context, to, text string) error {
+	if to == "" {
+		return ErrorNoRecipient
+	}
+	s.h.WithContext(ctx).Infof("sms to %s: %s", to, text)
+	return nil
+}
+
+// 一条已发送的短信
+type Message struct {
+	To   string
+	Text string
+}
+
+// MemorySender 只在内存中记录发送的短信，用于测试断言短信内容
+type MemorySender struct {
+	mu   sync.Mutex
+	msgs []Message
+	// 不为空时 Send 直接返回该错误，用于模拟发送失败
+	Err error
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (s *MemorySender) Send(ctx context.Context, to, text string) error {
+	if to == "" {
+		return ErrorNoRecipient
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	s.msgs = append(s.msgs, Message{To: to, Text: text})
+	return nil
+}
+
+// Last 返回最近一条发送给 to 的短信
+func (s *MemorySender) Last(to string) (Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.msgs) - 1; i >= 0; i-- {
+		if s.msgs[i].To == to {
+			return s.msgs[i], true
+		}
+	}
+	return Message{}, false
+}
@@ -0,0 +1,194 @@
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ID 的布局，从高位到低位：1 位保留为 0，41 位毫秒时间戳（相对 Epoch），10 位 worker ID，12 位序号。
+// 41 位时间戳可以使用约 69 年
+const (
+	TimestampBits = 41
+	WorkerBits    = 10
+	SequenceBits  = 12
+
+	MaxWorkerID = 1<<WorkerBits - 1
+	maxSequence = 1<<SequenceBits - 1
+	maxElapsed  = 1<<TimestampBits - 1
+
+	workerShift    = SequenceBits
+	timestampShift = SequenceBits + WorkerBits
+)
+
+// DefaultEpoch 2024-01-01 00:00:00 UTC，修改 Epoch 会导致新旧 ID 无法比较大小甚至重复
+var DefaultEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// DefaultMaxBackward 时钟回拨不超过该时长时等待追上，超过时返回错误
+const DefaultMaxBackward = 10 * time.Millisecond
+
+var (
+	ErrorInvalidWorkerID = fmt.Errorf("worker id must be between 0 and %d", MaxWorkerID)
+	ErrorClockBackwards  = errors.New("clock moved backwards")
+	ErrorEpochExhausted  = errors.New("timestamp exceeds the range of the epoch")
+	ErrorStopped         = errors.New("id generator is stopped")
+)
+
+type Options struct {
+	Epoch       time.Time
+	MaxBackward time.Duration
+	// 之前使用同一个 worker ID 生成的最后一个 ID 的时间，新的 ID 不会早于该时间
+	Last time.Time
+	// 获取当前时间，测试时替换
+	Now func() time.Time
+}
+
+type Option func(*Options)
+
+func WithEpoch(epoch time.Time) Option {
+	return func(o *Options) {
+		if !epoch.IsZero() {
+			o.Epoch = epoch
+		}
+	}
+}
+
+func WithMaxBackward(d time.Duration) Option {
+	return func(o *Options) {
+		if d > 0 {
+			o.MaxBackward = d
+		}
+	}
+}
+
+// WithLast 设置之前使用同一个 worker ID 时最后一个 ID 的时间，防止重启后时钟回拨产生重复 ID
+func WithLast(last time.Time) Option {
+	return func(o *Options) {
+		o.Last = last
+	}
+}
+
+func WithClock(now func() time.Time) Option {
+	return func(o *Options) {
+		o.Now = now
+	}
+}
+
+// Generator 单个 worker 内按时间递增的 64 位 ID 生成器，并发安全
+type Generator struct {
+	opt      Options
+	workerID int64
+
+	mu       sync.Mutex
+	last     int64 // 上一个 ID 的毫秒时间戳（相对 Epoch）
+	sequence int64
+	stopped  bool
+}
+
+func NewGenerator(workerID int64, opts ...Option) (*Generator, error) {
+	if workerID < 0 || workerID > MaxWorkerID {
+		return nil, ErrorInvalidWorkerID
+	}
+	opt := Options{
+		Epoch:       DefaultEpoch,
+		MaxBackward: DefaultMaxBackward,
+		Now:         time.Now,
+	}
+	for _, o := range opts {
+		o(&opt)
+	}
+	g := &Generator{opt: opt, workerID: workerID, last: -1}
+	if opt.Last.After(opt.Epoch) {
+		g.last = opt.Last.Sub(opt.Epoch).Milliseconds()
+		// 上一个 ID 所在的毫秒内序号可能已经用完，从下一毫秒开始
+		g.sequence = maxSequence
+	}
+	return g, nil
+}
+
+func (g *Generator) WorkerID() int64 {
+	return g.workerID
+}
+
+// Next 生成下一个 ID。同一毫秒内序号用尽时等待下一毫秒；
+// 时钟小幅回拨时等待追上上一个 ID 的时间，回拨超过 MaxBackward 时返回 ErrorClockBackwards
+func (g *Generator) Next() (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.stopped {
+		return 0, ErrorStopped
+	}
+	now := g.elapsed()
+	if now < g.last {
+		backward := time.Duration(g.last-now) * time.Millisecond
+		if backward > g.opt.MaxBackward {
+			return 0, fmt.Errorf("%w by %s", ErrorClockBackwards, backward)
+		}
+		now = g.waitUntil(g.last)
+	}
+	if now == g.last {
+		g.sequence = (g.sequence + 1) & maxSequence
+		if g.sequence == 0 {
+			now = g.waitUntil(g.last + 1)
+		}
+	} else {
+		g.sequence = 0
+	}
+	if now > maxElapsed {
+		return 0, ErrorEpochExhausted
+	}
+	g.last = now
+	return uint64(now)<<timestampShift | uint64(g.workerID)<<workerShift | uint64(g.sequence), nil
+}
+
+// Last 返回最后一个 ID 的时间，没有生成过 ID 且没有设置 WithLast 时返回零值
+func (g *Generator) Last() time.Time {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.last < 0 {
+		return time.Time{}
+	}
+	return g.opt.Epoch.Add(time.Duration(g.last) * time.Millisecond)
+}
+
+// Stop 之后 Next 都返回 ErrorStopped，用于 worker ID 的租约丢失后停止发号，避免与其他实例重复
+func (g *Generator) Stop() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.stopped = true
+}
+
+// Decode 按生成器的 Epoch 解析 ID
+func (g *Generator) Decode(id uint64) Parts {
+	return Decode(id, g.opt.Epoch)
+}
+
+func (g *Generator) elapsed() int64 {
+	return g.opt.Now().Sub(g.opt.Epoch).Milliseconds()
+}
+
+func (g *Generator) waitUntil(target int64) int64 {
+	now := g.elapsed()
+	for now < target {
+		time.Sleep(time.Duration(target-now) * time.Millisecond)
+		now = g.elapsed()
+	}
+	return now
+}
+
+// Parts ID 中的各个组成部分
+type Parts struct {
+	Time     time.Time
+	WorkerID int64
+	Sequence int64
+}
+
+// Decode 解析 ID 的生成时间、worker ID 与序号，epoch 需要与生成时使用的一致
+func Decode(id uint64, epoch time.Time) Parts {
+	return Parts{
+		Time:     epoch.Add(time.Duration(id>>timestampShift) * time.Millisecond),
+		WorkerID: int64(id>>workerShift) & MaxWorkerID,
+		Sequence: int64(id) & maxSequence,
+	}
+}
@@ -0,0 +1,139 @@
+package snowflake
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock 手动设置的时钟，每次读取后前进 step，用于模拟生成器等待期间时间的流逝
+type fakeClock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.now
+	c.now = c.now.Add(c.step)
+	return t
+}
+
+func (c *fakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+func TestGenerator_DecodeAndOrder(t *testing.T) {
+	start := DefaultEpoch.Add(123456 * time.Millisecond)
+	clock := &fakeClock{now: start}
+	g, err := NewGenerator(37, WithClock(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var prev uint64
+	for i := 0; i < 3; i++ {
+		id, err := g.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id <= prev {
+			t.Fatalf("ids are not increasing: %d after %d", id, prev)
+		}
+		prev = id
+		parts := g.Decode(id)
+		if !parts.Time.Equal(start) || parts.WorkerID != 37 || parts.Sequence != int64(i) {
+			t.Errorf("unexpected parts %+v of id %d", parts, id)
+		}
+	}
+}
+
+func TestGenerator_SequenceOverflow(t *testing.T) {
+	clock := &fakeClock{now: DefaultEpoch.Add(time.Second)}
+	g, _ := NewGenerator(1, WithClock(clock.Now))
+	seen := make(map[uint64]bool)
+	for i := 0; i <= maxSequence; i++ {
+		id, err := g.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[id] = true
+	}
+	// 序号用尽后需要等到下一毫秒
+	clock.step = time.Millisecond
+	id, err := g.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seen[id] {
+		t.Fatalf("duplicate id %d", id)
+	}
+	parts := g.Decode(id)
+	if parts.Sequence != 0 || !parts.Time.After(DefaultEpoch.Add(time.Second)) {
+		t.Errorf("unexpected parts after overflow %+v", parts)
+	}
+}
+
+func TestGenerator_ClockBackwards(t *testing.T) {
+	base := DefaultEpoch.Add(time.Hour)
+	clock := &fakeClock{now: base}
+	g, _ := NewGenerator(2, WithClock(clock.Now), WithMaxBackward(5*time.Millisecond))
+	first, err := g.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 小幅回拨时等待时钟追上
+	clock.Set(base.Add(-3 * time.Millisecond))
+	clock.step = time.Millisecond
+	second, err := g.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second <= first {
+		t.Fatalf("id went backwards: %d after %d", second, first)
+	}
+	// 回拨过大时报错
+	clock.Set(base.Add(-time.Second))
+	clock.step = 0
+	if _, err = g.Next(); !errors.Is(err, ErrorClockBackwards) {
+		t.Fatalf("expected ErrorClockBackwards, got %v", err)
+	}
+}
+
+func TestGenerator_Stop(t *testing.T) {
+	g, _ := NewGenerator(3)
+	if _, err := g.Next(); err != nil {
+		t.Fatal(err)
+	}
+	g.Stop()
+	if _, err := g.Next(); !errors.Is(err, ErrorStopped) {
+		t.Fatalf("expected ErrorStopped, got %v", err)
+	}
+}
+
+func TestGenerator_WithLast(t *testing.T) {
+	base := DefaultEpoch.Add(time.Hour)
+	clock := &fakeClock{now: base}
+	// 重启前最后一个 ID 的时间晚于当前时钟太多时拒绝发号
+	g, _ := NewGenerator(4, WithClock(clock.Now), WithLast(base.Add(time.Minute)))
+	if _, err := g.Next(); !errors.Is(err, ErrorClockBackwards) {
+		t.Fatalf("expected ErrorClockBackwards, got %v", err)
+	}
+	g, _ = NewGenerator(4, WithClock(clock.Now), WithLast(base.Add(-time.Minute)))
+	if _, err := g.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if !g.Last().Equal(base) {
+		t.Errorf("unexpected last %s", g.Last())
+	}
+}
+
+func TestNewGenerator_InvalidWorker(t *testing.T) {
+	if _, err := NewGenerator(MaxWorkerID + 1); !errors.Is(err, ErrorInvalidWorkerID) {
+		t.Fatalf("expected ErrorInvalidWorkerID, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package token
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrorTokenInvalid = errors.New("token is invalid")
+	ErrorTokenExpired = errors.New("token is expired")
+)
+
+// 访问令牌携带的声明
+type Claims struct {
+	// 令牌所属的会话，会话被撤销后令牌随之失效
+	SessionID string `json:"sid,omitempty"`
+	// 令牌所属用户的角色
+	Roles []string `json:"roles,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (c *Claims) UserID() (uint64, error) {
+	return strconv.ParseUint(c.Subject, 10, 64)
+}
+
+// Manager 负责访问令牌的签发与校验
+type Manager struct {
+	keys *KeySet
+	opt  *Options
+}
+
+func NewManager(keys *KeySet, opts ...Option) *Manager {
+	return &Manager{keys: keys, opt: NewOptions(opts...)}
+}
+
+func (m *Manager) Options() *Options {
+	return m.opt
+}
+
+// Issue 为用户的某个会话签发访问令牌
+func (m *Manager) Issue(userID uint64, sessionID string, roles []string) (string, *Claims, error) {
+	now := time.Now()
+	key, err := m.keys.Signing(now)
+	if err != nil {
+		return "", nil, err
+	}
+	id, err := RandomString(16)
+	if err != nil {
+		return "", nil, err
+	}
+	claims := &Claims{
+		SessionID: sessionID,
+		Roles:     roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Issuer:    m.opt.Issuer,
+			Subject:   strconv.FormatUint(userID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.opt.AccessTTL)),
+		},
+	}
+	if m.opt.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{m.opt.Audience}
+	}
+	t := jwt.NewWithClaims(key.method, claims)
+	t.Header["kid"] = key.kid
+	signed, err := t.SignedString(key.signKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return signed, claims, nil
+}
+
+// Parse 校验访问令牌的签名、签发方、接收方与有效期
+func (m *Manager) Parse(token string) (*Claims, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(m.keys.algorithms()),
+		jwt.WithExpirationRequired(),
+	}
+	if m.opt.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(m.opt.Issuer))
+	}
+	if m.opt.Audience != "" {
+		opts = append(opts, jwt.WithAudience(m.opt.Audience))
+	}
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, m.keyFunc, opts...)
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, ErrorTokenExpired
+	}
+	if err != nil {
+		return nil, ErrorTokenInvalid
+	}
+	return claims, nil
+}
+
+// 根据令牌头部的 kid 选择验签密钥，并要求令牌的算法与密钥一致
+func (m *Manager) keyFunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	key, ok := m.keys.Lookup(kid, time.Now())
+	if !ok {
+		return nil, ErrorTokenInvalid
+	}
+	if t.Method.Alg() != key.method.Alg() {
+		return nil, ErrorTokenInvalid
+	}
+	return key.verifyKey, nil
+}
+
+// JWKS 返回当前可用于验签的公钥集合
+func (m *Manager) JWKS() []JWK {
+	return m.keys.JWKS(time.Now())
+}
+
+// RandomString 生成 n 字节随机数的 base64url 编码，用作不透明令牌
+func RandomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
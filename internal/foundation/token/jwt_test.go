@@ -0,0 +1,141 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func pemKey(t *testing.T, priv interface{}) string {
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func testKeys(t *testing.T) map[string]*KeyConfig {
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]*KeyConfig{
+		AlgorithmHS256: {Algorithm: AlgorithmHS256, Secret: "0123456789abcdef0123456789abcdef"},
+		AlgorithmEdDSA: {Algorithm: AlgorithmEdDSA, PrivateKey: pemKey(t, edPriv)},
+		AlgorithmRS256: {Algorithm: AlgorithmRS256, PrivateKey: pemKey(t, rsaPriv)},
+	}
+}
+
+func TestManager_IssueAndParse(t *testing.T) {
+	for alg, c := range testKeys(t) {
+		key, err := NewKey(c)
+		if err != nil {
+			t.Fatalf("%s: %v", alg, err)
+		}
+		ks, _ := NewKeySet(key)
+		m := NewManager(ks, WithIssuer("user_service"), WithAudience("tiktok"))
+		signed, _, err := m.Issue(42, "sid", []string{"user"})
+		if err != nil {
+			t.Fatalf("%s: %v", alg, err)
+		}
+		claims, err := m.Parse(signed)
+		if err != nil {
+			t.Fatalf("%s: %v", alg, err)
+		}
+		if id, _ := claims.UserID(); id != 42 || claims.SessionID != "sid" {
+			t.Fatalf("%s: expected user 42 of session sid, got %d of %q", alg, id, claims.SessionID)
+		}
+
+		other := NewManager(ks, WithIssuer("someone_else"), WithAudience("tiktok"))
+		if _, err = other.Parse(signed); !errors.Is(err, ErrorTokenInvalid) {
+			t.Fatalf("%s: expected issuer mismatch to be rejected, got %v", alg, err)
+		}
+	}
+}
+
+func TestManager_Expired(t *testing.T) {
+	key, _ := NewKey(&KeyConfig{Algorithm: AlgorithmHS256, Secret: "secret"})
+	ks, _ := NewKeySet(key)
+	m := NewManager(ks, WithAccessTTL(time.Nanosecond))
+	signed, _, err := m.Issue(1, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Second)
+	if _, err = m.Parse(signed); !errors.Is(err, ErrorTokenExpired) {
+		t.Fatalf("expected expired token, got %v", err)
+	}
+}
+
+func TestManager_WrongKey(t *testing.T) {
+	k1, _ := NewKey(&KeyConfig{Algorithm: AlgorithmHS256, Secret: "secret-1"})
+	k2, _ := NewKey(&KeyConfig{Algorithm: AlgorithmHS256, Secret: "secret-2"})
+	ks1, _ := NewKeySet(k1)
+	ks2, _ := NewKeySet(k2)
+	signed, _, _ := NewManager(ks1).Issue(1, "", nil)
+	if _, err := NewManager(ks2).Parse(signed); !errors.Is(err, ErrorTokenInvalid) {
+		t.Fatalf("expected signature mismatch to be rejected, got %v", err)
+	}
+}
+
+func TestManager_Rotation(t *testing.T) {
+	keys := testKeys(t)
+	now := time.Now()
+
+	oldCfg := *keys[AlgorithmEdDSA]
+	oldCfg.Kid = "old"
+	oldKey, _ := NewKey(&oldCfg)
+	oldSigned, _, err := NewManager(mustKeySet(t, oldKey)).Issue(1, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newCfg := *keys[AlgorithmRS256]
+	newCfg.Kid = "new"
+	newCfg.NotBefore = now.Add(-time.Minute)
+	newKey, _ := NewKey(&newCfg)
+	m := NewManager(mustKeySet(t, oldKey, newKey))
+
+	// 新密钥生效后使用新密钥签名，旧密钥签发的令牌仍然有效
+	if _, err = m.Parse(oldSigned); err != nil {
+		t.Fatalf("token signed by the old key should still verify, got %v", err)
+	}
+	newSigned, _, _ := m.Issue(1, "", nil)
+	parsed, _ := jwt.Parse(newSigned, nil)
+	if parsed.Header["kid"] != "new" {
+		t.Fatalf("expected the newest key to sign, got kid %v", parsed.Header["kid"])
+	}
+	if jwks := m.JWKS(); len(jwks) != 2 {
+		t.Fatalf("expected 2 public keys, got %d", len(jwks))
+	}
+
+	// 旧密钥下线后，其签发的令牌不再被接受
+	oldCfg.NotAfter = now.Add(-time.Second)
+	retired, _ := NewKey(&oldCfg)
+	m = NewManager(mustKeySet(t, retired, newKey))
+	if _, err = m.Parse(oldSigned); !errors.Is(err, ErrorTokenInvalid) {
+		t.Fatalf("token signed by a retired key should be rejected, got %v", err)
+	}
+	if jwks := m.JWKS(); len(jwks) != 1 || jwks[0].Kid != "new" {
+		t.Fatalf("retired key should not be published, got %+v", jwks)
+	}
+}
+
+func mustKeySet(t *testing.T, keys ...*Key) *KeySet {
+	ks, err := NewKeySet(keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ks
+}
@@ -0,0 +1,178 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrorUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrorMissingKey           = errors.New("signing key is empty")
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmRS256 = "RS256"
+)
+
+type KeyConfig struct {
+	// 密钥 ID，写入令牌头部的 kid，为空时对非对称密钥使用 RFC 7638 指纹
+	Kid string
+	// HS256 | EdDSA | RS256
+	Algorithm string
+	// HS256 使用的共享密钥
+	Secret string
+	// EdDSA/RS256 使用的 PEM 格式私钥，为空时从 PrivateKeyFile 读取
+	PrivateKey     string
+	PrivateKeyFile string
+	// 密钥生效时间，零值表示立即生效
+	NotBefore time.Time
+	// 密钥失效时间，零值表示永不失效
+	NotAfter time.Time
+}
+
+// 签名密钥
+type Key struct {
+	kid    string
+	method jwt.SigningMethod
+	// 签名使用的密钥
+	signKey interface{}
+	// 验签使用的密钥
+	verifyKey interface{}
+	notBefore time.Time
+	notAfter  time.Time
+}
+
+func NewKey(c *KeyConfig) (*Key, error) {
+	var key *Key
+	switch c.Algorithm {
+	case AlgorithmHS256:
+		if c.Secret == "" {
+			return nil, ErrorMissingKey
+		}
+		secret := []byte(c.Secret)
+		key = &Key{method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret}
+	case AlgorithmEdDSA:
+		pem, err := loadPEM(c)
+		if err != nil {
+			return nil, err
+		}
+		priv, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("parse EdDSA private key err:%w", err)
+		}
+		pub, err := publicKey(priv)
+		if err != nil {
+			return nil, err
+		}
+		key = &Key{method: jwt.SigningMethodEdDSA, signKey: priv, verifyKey: pub}
+	case AlgorithmRS256:
+		pem, err := loadPEM(c)
+		if err != nil {
+			return nil, err
+		}
+		priv, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("parse RS256 private key err:%w", err)
+		}
+		key = &Key{method: jwt.SigningMethodRS256, signKey: priv, verifyKey: &priv.PublicKey}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrorUnsupportedAlgorithm, c.Algorithm)
+	}
+	key.kid = c.Kid
+	key.notBefore = c.NotBefore
+	key.notAfter = c.NotAfter
+	if key.kid == "" {
+		key.kid = key.thumbprint()
+	}
+	return key, nil
+}
+
+func (k *Key) Kid() string {
+	return k.kid
+}
+
+// 密钥在 now 时刻是否可以用于签名
+func (k *Key) signable(now time.Time) bool {
+	return !now.Before(k.notBefore) && k.verifiable(now)
+}
+
+// 密钥在 now 时刻是否可以用于验签，尚未生效的密钥同样可以验签，便于提前发布
+func (k *Key) verifiable(now time.Time) bool {
+	return k.notAfter.IsZero() || now.Before(k.notAfter)
+}
+
+// JWK 返回公钥的 JWK 表示，对称密钥不对外公开
+func (k *Key) JWK() (JWK, bool) {
+	jwk := JWK{Kid: k.kid, Alg: k.method.Alg(), Use: "sig"}
+	switch pub := k.verifyKey.(type) {
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	default:
+		return JWK{}, false
+	}
+	return jwk, true
+}
+
+// RFC 7638 指纹，对称密钥没有公开的指纹，使用默认 kid
+func (k *Key) thumbprint() string {
+	jwk, ok := k.JWK()
+	if !ok {
+		return "default"
+	}
+	// 按 RFC 7638 的要求，只保留必需字段并按字典序排列
+	var members interface{}
+	switch jwk.Kty {
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	default:
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	}
+	body, _ := json.Marshal(members)
+	sum := sha256.Sum256(body)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func loadPEM(c *KeyConfig) ([]byte, error) {
+	if c.PrivateKey != "" {
+		return []byte(c.PrivateKey), nil
+	}
+	if c.PrivateKeyFile == "" {
+		return nil, ErrorMissingKey
+	}
+	return os.ReadFile(c.PrivateKeyFile)
+}
+
+func publicKey(priv interface{}) (interface{}, error) {
+	signer, ok := priv.(interface{ Public() crypto.PublicKey })
+	if !ok {
+		return nil, fmt.Errorf("%w: private key has no public part", ErrorUnsupportedAlgorithm)
+	}
+	return signer.Public(), nil
+}
@@ -0,0 +1,97 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrorNoSigningKey   = errors.New("no signing key is valid now")
+	ErrorDuplicateKeyID = errors.New("duplicate key id")
+)
+
+// JSON Web Key，只包含验签需要的公开字段
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+}
+
+// KeySet 管理多把签名密钥：使用最新生效的密钥签名，接受所有仍在有效期内的密钥验签
+type KeySet struct {
+	keys []*Key
+	kids map[string]*Key
+}
+
+func NewKeySet(keys ...*Key) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, ErrorMissingKey
+	}
+	ks := &KeySet{keys: keys, kids: make(map[string]*Key, len(keys))}
+	for _, k := range keys {
+		if _, ok := ks.kids[k.kid]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrorDuplicateKeyID, k.kid)
+		}
+		ks.kids[k.kid] = k
+	}
+	return ks, nil
+}
+
+// Signing 返回 now 时刻用于签名的密钥：生效时间最晚的可用密钥，生效时间相同时取配置中靠后的
+func (ks *KeySet) Signing(now time.Time) (*Key, error) {
+	var current *Key
+	for _, k := range ks.keys {
+		if !k.signable(now) {
+			continue
+		}
+		if current == nil || !k.notBefore.Before(current.notBefore) {
+			current = k
+		}
+	}
+	if current == nil {
+		return nil, ErrorNoSigningKey
+	}
+	return current, nil
+}
+
+// Lookup 根据 kid 查找 now 时刻可以验签的密钥
+func (ks *KeySet) Lookup(kid string, now time.Time) (*Key, bool) {
+	k, ok := ks.kids[kid]
+	if !ok || !k.verifiable(now) {
+		return nil, false
+	}
+	return k, true
+}
+
+// JWKS 返回所有仍可验签的非对称密钥的公钥
+func (ks *KeySet) JWKS(now time.Time) []JWK {
+	jwks := make([]JWK, 0, len(ks.keys))
+	for _, k := range ks.keys {
+		if !k.verifiable(now) {
+			continue
+		}
+		if jwk, ok := k.JWK(); ok {
+			jwks = append(jwks, jwk)
+		}
+	}
+	return jwks
+}
+
+func (ks *KeySet) algorithms() []string {
+	seen := make(map[string]bool)
+	var algs []string
+	for _, k := range ks.keys {
+		alg := k.method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
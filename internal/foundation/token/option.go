@@ -0,0 +1,68 @@
+package token
+
+import "time"
+
+type Options struct {
+	// 令牌签发方，写入 iss
+	Issuer string
+	// 令牌接收方，写入 aud
+	Audience string
+	// 访问令牌有效期
+	AccessTTL time.Duration
+	// 刷新令牌有效期
+	RefreshTTL time.Duration
+}
+
+type Option func(*Options)
+
+const (
+	// 默认的访问令牌有效期为 15 min
+	DefaultAccessTTL = 15 * time.Minute
+	// 默认的刷新令牌有效期为 7 天
+	DefaultRefreshTTL = 7 * 24 * time.Hour
+)
+
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		AccessTTL:  DefaultAccessTTL,
+		RefreshTTL: DefaultRefreshTTL,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	repair(options)
+	return options
+}
+
+func WithIssuer(issuer string) Option {
+	return func(o *Options) {
+		o.Issuer = issuer
+	}
+}
+
+func WithAudience(audience string) Option {
+	return func(o *Options) {
+		o.Audience = audience
+	}
+}
+
+func WithAccessTTL(ttl time.Duration) Option {
+	return func(o *Options) {
+		o.AccessTTL = ttl
+	}
+}
+
+func WithRefreshTTL(ttl time.Duration) Option {
+	return func(o *Options) {
+		o.RefreshTTL = ttl
+	}
+}
+
+func repair(o *Options) {
+	if o.AccessTTL <= 0 {
+		o.AccessTTL = DefaultAccessTTL
+	}
+	if o.RefreshTTL <= 0 {
+		o.RefreshTTL = DefaultRefreshTTL
+	}
+}
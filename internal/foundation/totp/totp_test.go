@@ -0,0 +1,76 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 附录 B 中 SHA1 的测试向量，取后 6 位
+func TestCode_RFC6238(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, expect := range cases {
+		got, err := Code(secret, time.Unix(unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != expect {
+			t.Errorf("at %d expected %s, got %s", unix, expect, got)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	previous, _ := Code(secret, now.Add(-Period))
+	step, ok := Validate(secret, previous, now, 1)
+	if !ok || step != Step(now)-1 {
+		t.Fatalf("expected previous step to be accepted, got %d %v", step, ok)
+	}
+	old, _ := Code(secret, now.Add(-3*Period))
+	if _, ok = Validate(secret, old, now, 1); ok {
+		t.Fatal("expected code outside skew to be rejected")
+	}
+	if _, ok = Validate("not base32!", previous, now, 1); ok {
+		t.Fatal("expected invalid secret to be rejected")
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Tiktok Commence", "a@qq.com", "JBSWY3DPEHPK3PXP")
+	if !strings.HasPrefix(uri, "otpauth://totp/Tiktok%20Commence:a@qq.com?") || !strings.Contains(uri, "secret=JBSWY3DPEHPK3PXP") {
+		t.Fatalf("unexpected uri %s", uri)
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[string]bool)
+	for _, c := range codes {
+		if len(c) != 11 || c[5] != '-' {
+			t.Fatalf("unexpected recovery code %s", c)
+		}
+		seen[c] = true
+	}
+	if len(seen) != len(codes) {
+		t.Fatal("expected recovery codes to be unique")
+	}
+	if HashRecoveryCode(codes[0]) != HashRecoveryCode(" "+strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))) {
+		t.Fatal("expected hash to ignore case and separators")
+	}
+}
@@ -0,0 +1,105 @@
+package verifycode
+
+import "time"
+
+type Options struct {
+	// 验证码位数，只包含数字
+	Length int
+	// 验证码有效期
+	TTL time.Duration
+	// 单个验证码允许校验的次数，用尽后验证码作废
+	MaxAttempts int64
+	// 同一用途、同一地址两次发送的最小间隔
+	Cooldown time.Duration
+	// 同一地址每天最多发送的验证码数量
+	DailyQuota int64
+	// 计算验证码摘要使用的密钥，避免拿到 redis 数据后直接穷举出验证码
+	Secret string
+	// 额外保存验证码明文供 Peek 读取，只能在开发、测试环境开启
+	ExposeCodes bool
+}
+
+type Option func(*Options)
+
+const (
+	DefaultLength      = 6
+	DefaultTTL         = 5 * time.Minute
+	DefaultMaxAttempts = 5
+	DefaultCooldown    = time.Minute
+	DefaultDailyQuota  = 20
+)
+
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		Length:      DefaultLength,
+		TTL:         DefaultTTL,
+		MaxAttempts: DefaultMaxAttempts,
+		Cooldown:    DefaultCooldown,
+		DailyQuota:  DefaultDailyQuota,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	repair(options)
+	return options
+}
+
+func WithLength(length int) Option {
+	return func(o *Options) {
+		o.Length = length
+	}
+}
+
+func WithTTL(ttl time.Duration) Option {
+	return func(o *Options) {
+		o.TTL = ttl
+	}
+}
+
+func WithMaxAttempts(attempts int64) Option {
+	return func(o *Options) {
+		o.MaxAttempts = attempts
+	}
+}
+
+func WithCooldown(cooldown time.Duration) Option {
+	return func(o *Options) {
+		o.Cooldown = cooldown
+	}
+}
+
+func WithDailyQuota(quota int64) Option {
+	return func(o *Options) {
+		o.DailyQuota = quota
+	}
+}
+
+func WithSecret(secret string) Option {
+	return func(o *Options) {
+		o.Secret = secret
+	}
+}
+
+func WithExposeCodes(expose bool) Option {
+	return func(o *Options) {
+		o.ExposeCodes = expose
+	}
+}
+
+func repair(o *Options) {
+	if o.Length < 4 {
+		o.Length = DefaultLength
+	}
+	if o.TTL <= 0 {
+		o.TTL = DefaultTTL
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultMaxAttempts
+	}
+	if o.Cooldown < 0 {
+		o.Cooldown = DefaultCooldown
+	}
+	if o.DailyQuota <= 0 {
+		o.DailyQuota = DefaultDailyQuota
+	}
+}
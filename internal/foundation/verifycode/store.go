@@ -0,0 +1,239 @@
+package verifycode
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/TiktokCommence/userService/internal/foundation/cache"
+	"github.com/TiktokCommence/userService/internal/foundation/common"
+	"math/big"
+	"time"
+)
+
+var (
+	ErrorCodeInvalid     = errors.New("verify code is invalid or expired")
+	ErrorTooManyAttempts = errors.New("verify code attempts exceeded")
+	ErrorCooldown        = errors.New("verify code was sent too recently")
+	ErrorQuotaExceeded   = errors.New("daily verify code quota exceeded")
+	ErrorNotExposed      = errors.New("verify codes are not exposed")
+)
+
+// CooldownError 发送过于频繁，Remaining 为距离下一次可以发送的时长
+type CooldownError struct {
+	Remaining time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrorCooldown, e.Remaining)
+}
+
+func (e *CooldownError) Is(target error) bool {
+	return target == ErrorCooldown
+}
+
+// 保存在缓存中的验证码，只保存摘要
+type record struct {
+	Hash   string `json:"hash"`
+	SentAt int64  `json:"sent_at"`
+}
+
+// Store 按用途与地址隔离的验证码存储，不同用途的验证码互相不能使用
+type Store struct {
+	c   common.Cache
+	opt *Options
+}
+
+func NewStore(c common.Cache, opts ...Option) *Store {
+	return &Store{c: c, opt: NewOptions(opts...)}
+}
+
+func (s *Store) Options() Options {
+	return *s.opt
+}
+
+// Issue 生成并保存新的验证码，会覆盖同一用途、同一地址下未使用的旧验证码
+func (s *Store) Issue(ctx context.Context, purpose, target string) (string, error) {
+	if err := s.checkCooldown(ctx, purpose, target); err != nil {
+		return "", err
+	}
+	quotaKey := s.generateQuotaKey(target, time.Now())
+	sent, err := s.c.IncrBy(ctx, quotaKey, 1)
+	if err != nil {
+		return "", err
+	}
+	if sent == 1 {
+		if err = s.c.Expire(ctx, quotaKey, int64(24*time.Hour/time.Second)); err != nil {
+			return "", err
+		}
+	}
+	if sent > s.opt.DailyQuota {
+		return "", ErrorQuotaExceeded
+	}
+	code, err := s.generateCode()
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(record{Hash: s.hash(purpose, target, code), SentAt: time.Now().Unix()})
+	if err != nil {
+		return "", err
+	}
+	if err = s.c.SetEx(ctx, s.generateKey(purpose, target), string(body), seconds(s.opt.TTL)); err != nil {
+		return "", err
+	}
+	// 新验证码重新计算校验次数
+	if err = s.c.Del(ctx, s.generateAttemptsKey(purpose, target)); err != nil {
+		return "", err
+	}
+	if s.opt.ExposeCodes {
+		if err = s.c.SetEx(ctx, s.generateExposedKey(purpose, target), code, seconds(s.opt.TTL)); err != nil {
+			return "", err
+		}
+	}
+	return code, nil
+}
+
+// Verify 校验验证码，校验通过后验证码立即失效；校验失败次数用尽后验证码同样失效
+func (s *Store) Verify(ctx context.Context, purpose, target, code string) error {
+	key := s.generateKey(purpose, target)
+	val, err := s.c.Get(ctx, key)
+	if errors.Is(err, cache.ErrorCacheMiss) {
+		return ErrorCodeInvalid
+	}
+	if err != nil {
+		return err
+	}
+	var r record
+	if err = json.Unmarshal([]byte(val), &r); err != nil {
+		return err
+	}
+	attemptsKey := s.generateAttemptsKey(purpose, target)
+	attempts, err := s.c.IncrBy(ctx, attemptsKey, 1)
+	if err != nil {
+		return err
+	}
+	if attempts == 1 {
+		if err = s.c.Expire(ctx, attemptsKey, seconds(s.opt.TTL)); err != nil {
+			return err
+		}
+	}
+	if attempts > s.opt.MaxAttempts {
+		return errors.Join(ErrorTooManyAttempts, s.c.Del(ctx, key))
+	}
+	if !hmac.Equal([]byte(r.Hash), []byte(s.hash(purpose, target, code))) {
+		if attempts == s.opt.MaxAttempts {
+			return errors.Join(ErrorTooManyAttempts, s.c.Del(ctx, key))
+		}
+		return ErrorCodeInvalid
+	}
+	// 并发校验同一个验证码时只有一个调用方能够成功
+	if _, err = s.c.GetDel(ctx, key); errors.Is(err, cache.ErrorCacheMiss) {
+		return ErrorCodeInvalid
+	} else if err != nil {
+		return err
+	}
+	if err = s.c.Del(ctx, attemptsKey); err != nil {
+		return err
+	}
+	return s.deleteExposed(ctx, purpose, target)
+}
+
+// Revoke 作废尚未使用的验证码并清除发送冷却，用于验证码没有成功送达时允许立即重发
+func (s *Store) Revoke(ctx context.Context, purpose, target string) error {
+	if err := s.c.Del(ctx, s.generateKey(purpose, target)); err != nil {
+		return err
+	}
+	if err := s.c.Del(ctx, s.generateCooldownKey(purpose, target)); err != nil {
+		return err
+	}
+	return s.deleteExposed(ctx, purpose, target)
+}
+
+// Peek 读取尚未使用的验证码明文，只有开启 ExposeCodes 时可用，供端到端测试读取验证码
+func (s *Store) Peek(ctx context.Context, purpose, target string) (string, error) {
+	if !s.opt.ExposeCodes {
+		return "", ErrorNotExposed
+	}
+	code, err := s.c.Get(ctx, s.generateExposedKey(purpose, target))
+	if errors.Is(err, cache.ErrorCacheMiss) {
+		return "", ErrorCodeInvalid
+	}
+	return code, err
+}
+
+func (s *Store) deleteExposed(ctx context.Context, purpose, target string) error {
+	if !s.opt.ExposeCodes {
+		return nil
+	}
+	return s.c.Del(ctx, s.generateExposedKey(purpose, target))
+}
+
+// checkCooldown 通过计数器的首次写入抢占冷却期，冷却期内再次发送返回 *CooldownError
+func (s *Store) checkCooldown(ctx context.Context, purpose, target string) error {
+	if s.opt.Cooldown == 0 {
+		return nil
+	}
+	cooldownKey := s.generateCooldownKey(purpose, target)
+	n, err := s.c.IncrBy(ctx, cooldownKey, 1)
+	if err != nil {
+		return err
+	}
+	if n == 1 {
+		return s.c.Expire(ctx, cooldownKey, seconds(s.opt.Cooldown))
+	}
+	remaining := s.opt.Cooldown
+	if val, err := s.c.Get(ctx, s.generateKey(purpose, target)); err == nil {
+		var r record
+		if json.Unmarshal([]byte(val), &r) == nil {
+			remaining = s.opt.Cooldown - time.Since(time.Unix(r.SentAt, 0))
+		}
+	}
+	return &CooldownError{Remaining: max(remaining, time.Second)}
+}
+
+func (s *Store) generateCode() (string, error) {
+	limit := big.NewInt(1)
+	for i := 0; i < s.opt.Length; i++ {
+		limit.Mul(limit, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*s", s.opt.Length, n.String()), nil
+}
+
+// hash 摘要绑定用途与地址，同一个验证码换一个用途或地址无法通过校验
+func (s *Store) hash(purpose, target, code string) string {
+	mac := hmac.New(sha256.New, []byte(s.opt.Secret))
+	mac.Write([]byte(purpose + "\x00" + target + "\x00" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Store) generateKey(purpose, target string) string {
+	return fmt.Sprintf("code:%s:%s", purpose, target)
+}
+
+func (s *Store) generateExposedKey(purpose, target string) string {
+	return fmt.Sprintf("code_exposed:%s:%s", purpose, target)
+}
+
+func (s *Store) generateAttemptsKey(purpose, target string) string {
+	return fmt.Sprintf("code_attempts:%s:%s", purpose, target)
+}
+
+func (s *Store) generateCooldownKey(purpose, target string) string {
+	return fmt.Sprintf("code_cooldown:%s:%s", purpose, target)
+}
+
+func (s *Store) generateQuotaKey(target string, now time.Time) string {
+	return fmt.Sprintf("code_quota:%s:%s", target, now.Format("20060102"))
+}
+
+func seconds(d time.Duration) int64 {
+	return max(int64(d/time.Second), 1)
+}
@@ -0,0 +1,223 @@
+package verifycode
+
+import (
+	"context"
+	"errors"
+	"github.com/TiktokCommence/userService/internal/foundation/cache"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memCache 测试使用的内存缓存，只实现验证码存储用到的操作，不处理过期
+type memCache struct {
+	mu sync.Mutex
+	m  map[string]string
+}
+
+func newMemCache() *memCache {
+	return &memCache{m: make(map[string]string)}
+}
+
+func (c *memCache) Enable(ctx context.Context, key string, delayMilis int64) error { return nil }
+func (c *memCache) Disable(ctx context.Context, key string, expireSeconds int64) error {
+	return nil
+}
+func (c *memCache) PutWhenEnable(ctx context.Context, key, value string, expireSeconds int64) (bool, error) {
+	return true, c.SetEx(ctx, key, value, expireSeconds)
+}
+func (c *memCache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.m[key]
+	if !ok {
+		return "", cache.ErrorCacheMiss
+	}
+	return v, nil
+}
+func (c *memCache) Del(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.m, key)
+	return nil
+}
+func (c *memCache) Set(ctx context.Context, key string, value interface{}) error {
+	return c.SetEx(ctx, key, value.(string), 0)
+}
+func (c *memCache) IncrBy(ctx context.Context, key string, step int64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, _ := strconv.ParseInt(c.m[key], 10, 64)
+	n += step
+	c.m[key] = strconv.FormatInt(n, 10)
+	return n, nil
+}
+func (c *memCache) SetEx(ctx context.Context, key, value string, expireSeconds int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[key] = value
+	return nil
+}
+func (c *memCache) GetDel(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.m[key]
+	if !ok {
+		return "", cache.ErrorCacheMiss
+	}
+	delete(c.m, key)
+	return v, nil
+}
+func (c *memCache) Expire(ctx context.Context, key string, expireSeconds int64) error { return nil }
+func (c *memCache) SAdd(ctx context.Context, key string, members ...string) error     { return nil }
+func (c *memCache) SRem(ctx context.Context, key string, members ...string) error     { return nil }
+func (c *memCache) SMembers(ctx context.Context, key string) ([]string, error)        { return nil, nil }
+func (c *memCache) SetNX(ctx context.Context, key, value string, expireSeconds int64) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.m[key]; ok {
+		return false, nil
+	}
+	c.m[key] = value
+	return true, nil
+}
+func (c *memCache) ExpireIfEqual(ctx context.Context, key, value string, expireSeconds int64) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.m[key] == value, nil
+}
+func (c *memCache) DelIfEqual(ctx context.Context, key, value string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.m[key] != value {
+		return false, nil
+	}
+	delete(c.m, key)
+	return true, nil
+}
+func (c *memCache) IncrEscalate(ctx context.Context, countKey, levelKey string, threshold, countExpireSeconds, levelExpireSeconds int64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	count, _ := strconv.ParseInt(c.m[countKey], 10, 64)
+	if count++; count < threshold {
+		c.m[countKey] = strconv.FormatInt(count, 10)
+		return 0, nil
+	}
+	delete(c.m, countKey)
+	level, _ := strconv.ParseInt(c.m[levelKey], 10, 64)
+	level++
+	c.m[levelKey] = strconv.FormatInt(level, 10)
+	return level, nil
+}
+
+func (c *memCache) Publish(ctx context.Context, channel, message string) error {
+	return nil
+}
+
+func (c *memCache) Subscribe(ctx context.Context, channel string, handle func(message string)) error {
+	<-ctx.Done()
+	return nil
+}
+
+func TestStore_IssueAndVerify(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore(newMemCache(), WithCooldown(0))
+	code, err := s.Issue(ctx, "register", "a@qq.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) != DefaultLength {
+		t.Fatalf("unexpected code %s", code)
+	}
+	// 其他用途的验证码不能使用
+	if err = s.Verify(ctx, "login", "a@qq.com", code); !errors.Is(err, ErrorCodeInvalid) {
+		t.Fatalf("expected code of other purpose to be rejected, got %v", err)
+	}
+	if err = s.Verify(ctx, "register", "a@qq.com", code); err != nil {
+		t.Fatal(err)
+	}
+	// 验证码只能使用一次
+	if err = s.Verify(ctx, "register", "a@qq.com", code); !errors.Is(err, ErrorCodeInvalid) {
+		t.Fatalf("expected used code to be rejected, got %v", err)
+	}
+}
+
+func TestStore_MaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore(newMemCache(), WithCooldown(0), WithMaxAttempts(3))
+	code, err := s.Issue(ctx, "reset", "a@qq.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrong := "x" + code[1:]
+	for i := 1; i <= 3; i++ {
+		err = s.Verify(ctx, "reset", "a@qq.com", wrong)
+		if i < 3 && !errors.Is(err, ErrorCodeInvalid) {
+			t.Fatalf("attempt %d: expected invalid code, got %v", i, err)
+		}
+	}
+	if !errors.Is(err, ErrorTooManyAttempts) {
+		t.Fatalf("expected attempts to be exhausted, got %v", err)
+	}
+	if err = s.Verify(ctx, "reset", "a@qq.com", code); !errors.Is(err, ErrorCodeInvalid) {
+		t.Fatalf("expected code to be revoked after too many attempts, got %v", err)
+	}
+}
+
+func TestStore_CooldownAndQuota(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore(newMemCache(), WithCooldown(time.Minute))
+	if _, err := s.Issue(ctx, "login", "a@qq.com"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := s.Issue(ctx, "login", "a@qq.com")
+	var cooldown *CooldownError
+	if !errors.As(err, &cooldown) || cooldown.Remaining <= 0 || cooldown.Remaining > time.Minute {
+		t.Fatalf("expected cooldown error, got %v", err)
+	}
+	// 撤销后允许立即重发
+	if err = s.Revoke(ctx, "login", "a@qq.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Issue(ctx, "login", "a@qq.com"); err != nil {
+		t.Fatalf("expected revoke to clear cooldown, got %v", err)
+	}
+
+	s = NewStore(newMemCache(), WithCooldown(0), WithDailyQuota(2))
+	for i := 0; i < 2; i++ {
+		if _, err = s.Issue(ctx, "login", "a@qq.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = s.Issue(ctx, "register", "a@qq.com"); !errors.Is(err, ErrorQuotaExceeded) {
+		t.Fatalf("expected quota to be shared by all purposes, got %v", err)
+	}
+}
+
+func TestStore_Peek(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore(newMemCache(), WithCooldown(0))
+	if _, err := s.Issue(ctx, "register", "a@qq.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Peek(ctx, "register", "a@qq.com"); !errors.Is(err, ErrorNotExposed) {
+		t.Fatalf("expected codes not to be exposed by default, got %v", err)
+	}
+
+	s = NewStore(newMemCache(), WithCooldown(0), WithExposeCodes(true))
+	code, err := s.Issue(ctx, "register", "a@qq.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	peeked, err := s.Peek(ctx, "register", "a@qq.com")
+	if err != nil || peeked != code {
+		t.Fatalf("expected to peek %s, got %s %v", code, peeked, err)
+	}
+	if err = s.Verify(ctx, "register", "a@qq.com", peeked); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Peek(ctx, "register", "a@qq.com"); !errors.Is(err, ErrorCodeInvalid) {
+		t.Fatalf("expected used code to be gone, got %v", err)
+	}
+}
@@ -0,0 +1,10 @@
+package model
+
+// 验证码的用途，不同用途的验证码分开存放，互相不能使用
+const (
+	CodePurposeRegister    = "register"
+	CodePurposeReset       = "reset"
+	CodePurposeChangeEmail = "change_email"
+	CodePurposeLogin       = "login"
+	CodePurposeVerifyPhone = "verify_phone"
+)
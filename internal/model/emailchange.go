@@ -0,0 +1,43 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const EmailChangeTableName = "user_email_changes"
+
+// EmailChange 修改绑定邮箱的审计记录，只追加不修改
+type EmailChange struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement;column:id"`
+	UserID    uint64 `gorm:"column:user_id;index"`
+	OldEmail  string `gorm:"column:old_email;type:varchar(100)"`
+	NewEmail  string `gorm:"column:new_email;type:varchar(100)"`
+	IP        string `gorm:"column:ip;type:varchar(64)"`
+	UserAgent string `gorm:"column:user_agent;type:varchar(255)"`
+	CreatedAt time.Time
+}
+
+func (e *EmailChange) KeyColumn() string {
+	return "id"
+}
+
+func (e *EmailChange) Key() interface{} {
+	return e.ID
+}
+
+func (e *EmailChange) Write() (string, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func (e *EmailChange) Read(body string) error {
+	return json.Unmarshal([]byte(body), e)
+}
+
+func (e *EmailChange) TableName() string {
+	return EmailChangeTableName
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const IDSegmentTableName = "id_segments"
+
+// IDSegment 号段发号器在数据库中的进度，max_id 为已经预留出去的最大 ID
+type IDSegment struct {
+	BizTag    string `gorm:"primaryKey;column:biz_tag;type:varchar(64)"`
+	MaxID     uint64 `gorm:"column:max_id"`
+	Step      uint64 `gorm:"column:step"`
+	UpdatedAt time.Time
+}
+
+func (s *IDSegment) KeyColumn() string {
+	return "biz_tag"
+}
+
+func (s *IDSegment) Key() interface{} {
+	return s.BizTag
+}
+
+func (s *IDSegment) Write() (string, error) {
+	body, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func (s *IDSegment) Read(body string) error {
+	return json.Unmarshal([]byte(body), s)
+}
+
+func (s *IDSegment) TableName() string {
+	return IDSegmentTableName
+}
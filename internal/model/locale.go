@@ -0,0 +1,16 @@
+package model
+
+import "context"
+
+type localeKey struct{}
+
+// WithLocale 在上下文中记录发送邮件使用的语言
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext 取出上下文中的语言，没有时返回空字符串
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
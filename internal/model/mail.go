@@ -0,0 +1,15 @@
+package model
+
+// 通知类邮件的类型，验证码邮件的类型为 code_<purpose>
+const (
+	MailKindWelcome       = "welcome"
+	MailKindSecurityAlert = "security_alert"
+)
+
+// 安全提醒邮件中的事件
+const (
+	SecurityEventPasswordChanged = "password_changed"
+	SecurityEventPasswordReset   = "password_reset"
+	// 发送到旧邮箱，data 中需要 NewEmail
+	SecurityEventEmailChanged = "email_changed"
+)
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	OutboxTableName = "email_outbox"
+
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+	// 重试次数用尽，需要人工处理
+	OutboxStatusDead = "dead"
+)
+
+// OutboxMessage 待异步发送的邮件，发送成功或转为 dead 后清空正文，避免验证码等内容长期留在数据库中
+type OutboxMessage struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement;column:id"`
+	// 相同幂等键的邮件只会入队一次
+	IdempotencyKey string `gorm:"column:idempotency_key;type:varchar(100);uniqueIndex"`
+	Recipient      string `gorm:"column:recipient;type:varchar(255)"`
+	Subject        string `gorm:"column:subject;type:varchar(255)"`
+	HTML           string `gorm:"column:html;type:text"`
+	Text           string `gorm:"column:text;type:text"`
+	Status         string `gorm:"column:status;type:varchar(20);index:idx_outbox_due,priority:1"`
+	Attempts       int    `gorm:"column:attempts"`
+	// 下一次可以尝试发送的时间，被领取后推迟到租约到期，防止多个实例重复发送
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;index:idx_outbox_due,priority:2"`
+	LastError     string     `gorm:"column:last_error;type:varchar(500)"`
+	SentAt        *time.Time `gorm:"column:sent_at;index"`
+	// 超过该时间仍未发送的邮件直接转为 dead，为空表示不过期
+	ExpiresAt *time.Time `gorm:"column:expires_at"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (o *OutboxMessage) KeyColumn() string {
+	return "id"
+}
+
+func (o *OutboxMessage) Key() interface{} {
+	return o.ID
+}
+
+func (o *OutboxMessage) Write() (string, error) {
+	body, err := json.Marshal(o)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func (o *OutboxMessage) Read(body string) error {
+	return json.Unmarshal([]byte(body), o)
+}
+
+func (o *OutboxMessage) TableName() string {
+	return OutboxTableName
+}
@@ -0,0 +1,7 @@
+package model
+
+// PasswordViolation 密码不满足的策略规则，Code 供客户端识别，Message 供展示
+type PasswordViolation struct {
+	Code    string
+	Message string
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 发起请求的客户端信息
+type ClientInfo struct {
+	Device    string
+	IP        string
+	UserAgent string
+}
+
+// 服务端保存的登录会话
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     uint64    `json:"user_id"`
+	Roles      []string  `json:"roles"`
+	Device     string    `json:"device"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+func (s *Session) Write() (string, error) {
+	body, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func (s *Session) Read(body string) error {
+	return json.Unmarshal([]byte(body), s)
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 登录成功后下发的令牌对
+type TokenPair struct {
+	UserID           uint64
+	SessionID        string
+	AccessToken      string
+	RefreshToken     string
+	AccessExpiresAt  time.Time
+	RefreshExpiresAt time.Time
+}
+
+// 访问令牌中携带的用户身份
+type TokenClaims struct {
+	ID        string
+	UserID    uint64
+	SessionID string
+	Roles     []string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+const (
+	TokenStateActive  = "active"
+	TokenStateExpired = "expired"
+	TokenStateRevoked = "revoked"
+	TokenStateInvalid = "invalid"
+)
+
+// 访问令牌的校验结果
+type TokenIntrospection struct {
+	Active bool
+	// active | expired | revoked | invalid
+	State  string
+	Claims TokenClaims
+}
+
+// 对外公开的验签公钥
+type JWK struct {
+	Kty string
+	Crv string
+	X   string
+	N   string
+	E   string
+	Kid string
+	Alg string
+	Use string
+}
+
+// 刷新令牌在缓存中保存的内容
+type RefreshToken struct {
+	UserID    uint64    `json:"user_id"`
+	SessionID string    `json:"session_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (r *RefreshToken) Write() (string, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func (r *RefreshToken) Read(body string) error {
+	return json.Unmarshal([]byte(body), r)
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const TwoFactorTableName = "user_two_factors"
+
+// TwoFactor 用户的 TOTP 二次验证状态，与 users 表一对一
+type TwoFactor struct {
+	UserID uint64 `gorm:"primaryKey;autoIncrement:false;column:user_id"`
+	Secret string `gorm:"column:secret;type:varchar(64)"`
+	// 确认首个验证码之前为 false，此时不要求二次验证
+	Enabled bool `gorm:"column:enabled"`
+	// 未使用恢复码摘要的 JSON 数组
+	RecoveryCodes string `gorm:"column:recovery_codes;type:text"`
+	// 最近一次通过校验的时间片，防止验证码被重放
+	LastUsedStep int64 `gorm:"column:last_used_step"`
+	EnabledAt    *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (t *TwoFactor) KeyColumn() string {
+	return "user_id"
+}
+
+func (t *TwoFactor) Key() interface{} {
+	return t.UserID
+}
+
+func (t *TwoFactor) Write() (string, error) {
+	body, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func (t *TwoFactor) Read(body string) error {
+	return json.Unmarshal([]byte(body), t)
+}
+
+func (t *TwoFactor) TableName() string {
+	return TwoFactorTableName
+}
+
+func (t *TwoFactor) RecoveryCodeHashes() []string {
+	var hashes []string
+	if t.RecoveryCodes == "" {
+		return hashes
+	}
+	_ = json.Unmarshal([]byte(t.RecoveryCodes), &hashes)
+	return hashes
+}
+
+func (t *TwoFactor) SetRecoveryCodeHashes(hashes []string) {
+	if hashes == nil {
+		hashes = []string{}
+	}
+	body, _ := json.Marshal(hashes)
+	t.RecoveryCodes = string(body)
+}
+
+// MFAChallenge 密码校验通过、等待二次验证的登录请求
+type MFAChallenge struct {
+	ID        string
+	UserID    uint64
+	Client    ClientInfo
+	ExpiresAt time.Time
+}
+
+func (c *MFAChallenge) Write() (string, error) {
+	body, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func (c *MFAChallenge) Read(body string) error {
+	return json.Unmarshal([]byte(body), c)
+}
@@ -2,26 +2,69 @@ package model
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
 const (
 	UserTableName = "users"
+	// 新用户默认的角色
+	RoleUser = "user"
 )
 
 type User struct {
-	ID        uint64  `gorm:"primaryKey;column:id"`
-	Password  string  `gorm:"column:password"`
-	Name      *string `gorm:"column:username;type:varchar(200)"`
-	Email     string  `gorm:"column:email;type:varchar(100);uniqueIndex"`
-	Age       *int32  `gorm:"column:age"`
-	Addr1     *string `gorm:"column:addr1;type:varchar(100)"`
-	Addr2     *string `gorm:"column:addr2;type:varchar(100)"`
-	Phone     *string `gorm:"column:phone;type:varchar(30)"`
+	ID       uint64  `gorm:"primaryKey;column:id"`
+	Password string  `gorm:"column:password"`
+	Name     *string `gorm:"column:username;type:varchar(200)"`
+	Email    string  `gorm:"column:email;type:varchar(100);uniqueIndex"`
+	Age      *int32  `gorm:"column:age"`
+	Addr1    *string `gorm:"column:addr1;type:varchar(100)"`
+	Addr2    *string `gorm:"column:addr2;type:varchar(100)"`
+	Phone    *string `gorm:"column:phone;type:varchar(30);index"`
+	// 手机号通过短信验证的时间，为空表示未验证，只有已验证的手机号可以用于登录
+	PhoneVerifiedAt *time.Time `gorm:"column:phone_verified_at"`
+	// 已验证的手机号，未验证时为空，由唯一索引保证同一个号码只能被一个用户验证。
+	// 升级前已验证的数据需要先处理重复号码，再执行
+	// UPDATE users SET verified_phone = phone WHERE phone_verified_at IS NOT NULL 回填
+	VerifiedPhone *string `gorm:"column:verified_phone;type:varchar(30);uniqueIndex"`
+	// 邮件使用的语言，为空时使用请求中的语言
+	Locale *string `gorm:"column:locale;type:varchar(20)"`
+	// 逗号分隔的角色列表
+	Roles     string `gorm:"column:roles;type:varchar(100);default:user"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// ProfileUpdate 用户修改的资料，为 nil 的字段保持不变
+type ProfileUpdate struct {
+	Name   *string
+	Age    *int32
+	Addr1  *string
+	Addr2  *string
+	Locale *string
+}
+
+// Columns 返回需要修改的列，只包含请求中设置了的字段
+func (p ProfileUpdate) Columns() map[string]interface{} {
+	columns := make(map[string]interface{})
+	if p.Name != nil {
+		columns["username"] = *p.Name
+	}
+	if p.Age != nil {
+		columns["age"] = *p.Age
+	}
+	if p.Addr1 != nil {
+		columns["addr1"] = *p.Addr1
+	}
+	if p.Addr2 != nil {
+		columns["addr2"] = *p.Addr2
+	}
+	if p.Locale != nil {
+		columns["locale"] = *p.Locale
+	}
+	return columns
+}
+
 func (u *User) KeyColumn() string {
 	return "id"
 }
@@ -42,6 +85,13 @@ func (u *User) Read(body string) error {
 	return json.Unmarshal([]byte(body), u)
 }
 
+func (u *User) RoleList() []string {
+	if u.Roles == "" {
+		return []string{RoleUser}
+	}
+	return strings.Split(u.Roles, ",")
+}
+
 func (u *User) TableName() string {
 	return UserTableName
 }
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"github.com/TiktokCommence/userService/internal/foundation/localcache"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"time"
+)
+
+var _ transport.Server = (*CacheInvalidationServer)(nil)
+
+// cacheResubscribeInterval 订阅断开后重新订阅的间隔
+const cacheResubscribeInterval = time.Second
+
+type CacheInvalidationListener interface {
+	// 订阅用户缓存的失效通知，直到 ctx 取消或连接断开
+	Listen(ctx context.Context) error
+}
+
+type CacheStats interface {
+	Stats() localcache.Stats
+}
+
+// CacheInvalidationServer 在应用生命周期内保持对缓存失效通知的订阅，断开后自动重新订阅
+type CacheInvalidationServer struct {
+	*runner
+	l CacheInvalidationListener
+	h *log.Helper
+}
+
+func NewCacheInvalidationServer(l CacheInvalidationListener, logger log.Logger) *CacheInvalidationServer {
+	s := &CacheInvalidationServer{
+		l: l,
+		h: log.NewHelper(logger),
+	}
+	s.runner = newRunner("cache", s.run, logger)
+	return s
+}
+
+func (s *CacheInvalidationServer) run(ctx context.Context) {
+	s.h.Info("[cache] invalidation listener started")
+	for {
+		err := s.l.Listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		s.h.Errorf("[cache] invalidation subscription lost, local cache disabled until resubscribed:%v", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(cacheResubscribeInterval):
+		}
+	}
+}
@@ -16,6 +16,8 @@ func NewGRPCServer(c *conf.Server, greeter *service.UserServiceService, logger l
 		grpc.Middleware(
 			recovery.Recovery(),
 			logging.Server(logger),
+			service.Locale(),
+			service.Admin(c.GetAdminToken()),
 		),
 	}
 	if c.Grpc.Network != "" {
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	stdhttp "net/http"
+
+	v1 "github.com/TiktokCommence/userService/api/user/v1"
+	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/TiktokCommence/userService/internal/service"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/logging"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// JWKSPath 是发布验签公钥的路径
+	JWKSPath = "/.well-known/jwks.json"
+	// DebugVerifyCodePath 是读取验证码的调试接口，只有 dev 环境配置开启 exposeCodes 时注册，需要管理员凭证
+	DebugVerifyCodePath = "/debug/verify-code"
+	// CacheStatsPath 返回进程内用户缓存的命中统计，只有配置开启 exposeStats 时注册
+	CacheStatsPath = "/debug/cache-stats"
+	// EnvDev 只有该环境允许开启 exposeCodes
+	EnvDev = "dev"
+)
+
+// ErrorExposeCodesNotDev 非 dev 环境开启 exposeCodes 时拒绝启动
+var ErrorExposeCodesNotDev = errors.New("exposeCodes can only be enabled when server env is dev")
+
+// NewHTTPServer new an HTTP server.
+func NewHTTPServer(c *conf.Server, vc *conf.VerifyCodeConf, cc *conf.CacheConf, greeter *service.UserServiceService, cs CacheStats, logger log.Logger) (*http.Server, error) {
+	if vc.GetExposeCodes() && c.GetEnv() != EnvDev {
+		return nil, ErrorExposeCodesNotDev
+	}
+	var opts = []http.ServerOption{
+		http.Middleware(
+			recovery.Recovery(),
+			logging.Server(logger),
+			service.Locale(),
+			service.Admin(c.GetAdminToken()),
+		),
+	}
+	if c.Http.GetNetwork() != "" {
+		opts = append(opts, http.Network(c.Http.Network))
+	}
+	if c.Http.GetAddr() != "" {
+		opts = append(opts, http.Address(c.Http.Addr))
+	}
+	if c.Http.GetTimeout() != nil {
+		opts = append(opts, http.Timeout(c.Http.Timeout.AsDuration()))
+	}
+	srv := http.NewServer(opts...)
+	srv.HandleFunc(JWKSPath, jwksHandler(greeter))
+	if vc.GetExposeCodes() {
+		log.NewHelper(logger).Warnf("verify codes are exposed on %s, never enable exposeCodes in production", DebugVerifyCodePath)
+		srv.HandleFunc(DebugVerifyCodePath, debugVerifyCodeHandler(greeter, c.GetAdminToken()))
+	}
+	if cc.GetExposeStats() {
+		srv.HandleFunc(CacheStatsPath, cacheStatsHandler(cs))
+	}
+	return srv, nil
+}
+
+// debugVerifyCodeHandler 按 purpose 与 email 查询参数返回尚未使用的验证码，
+// 短信验证码使用 phone 参数，号码需要是 E.164 格式
+func debugVerifyCodeHandler(greeter *service.UserServiceService, adminToken string) stdhttp.HandlerFunc {
+	return func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		if !service.AdminTokenValid(adminToken, r.Header.Get(service.AdminTokenHeader)) {
+			stdhttp.Error(w, service.ErrAdminRequired.Error(), stdhttp.StatusForbidden)
+			return
+		}
+		query := r.URL.Query()
+		target := query.Get("email")
+		if target == "" {
+			target = query.Get("phone")
+		}
+		code, err := greeter.PeekVerifyCode(r.Context(), query.Get("purpose"), target)
+		if err != nil {
+			stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(map[string]string{"code": code})
+	}
+}
+
+func jwksHandler(greeter *service.UserServiceService) stdhttp.HandlerFunc {
+	return func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		resp, err := greeter.GetJWKS(r.Context(), &v1.GetJWKSReq{})
+		if err != nil {
+			stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+			return
+		}
+		body, err := protojson.Marshal(resp)
+		if err != nil {
+			stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// 公钥轮换时新密钥会提前发布，客户端可以放心缓存一段时间
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		_, _ = w.Write(body)
+	}
+}
+
+func cacheStatsHandler(cs CacheStats) stdhttp.HandlerFunc {
+	return func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		stats := cs.Stats()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"hits":   stats.Hits,
+			"misses": stats.Misses,
+			"size":   stats.Size,
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"github.com/TiktokCommence/userService/internal/conf"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"time"
+)
+
+var _ transport.Server = (*OutboxServer)(nil)
+
+// DefaultOutboxPollInterval 发件箱为空时的默认轮询间隔
+const DefaultOutboxPollInterval = time.Second
+
+type OutboxDispatcher interface {
+	// 发送一批到期的邮件，返回本次领取到的邮件数量
+	DispatchOnce(ctx context.Context) (int, error)
+}
+
+// OutboxServer 在应用生命周期内持续发送发件箱中的邮件
+type OutboxServer struct {
+	*runner
+	d        OutboxDispatcher
+	interval time.Duration
+	h        *log.Helper
+}
+
+func NewOutboxServer(c *conf.OutboxConf, d OutboxDispatcher, logger log.Logger) *OutboxServer {
+	interval := c.GetPollInterval().AsDuration()
+	if interval <= 0 {
+		interval = DefaultOutboxPollInterval
+	}
+	s := &OutboxServer{
+		d:        d,
+		interval: interval,
+		h:        log.NewHelper(logger),
+	}
+	s.runner = newRunner("outbox", s.run, logger)
+	return s
+}
+
+func (s *OutboxServer) run(ctx context.Context) {
+	s.h.Infof("[outbox] server started, poll interval %s", s.interval)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		n, err := s.d.DispatchOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.h.Errorf("[outbox] dispatch error:%v", err)
+		}
+		// 领取到邮件时说明可能还有积压，立即继续发送
+		if n > 0 && err == nil {
+			timer.Reset(0)
+		} else {
+			timer.Reset(s.interval)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/log"
+	"sync/atomic"
+)
+
+// runner 在应用生命周期内运行一个后台循环，供不监听端口的 transport.Server 复用。
+// 停止用的 ctx 在构造时创建，Start 与 Stop 可以在不同的 goroutine 中以任意顺序调用，
+// Stop 先于 Start 调用时循环不会再运行
+type runner struct {
+	name    string
+	h       *log.Helper
+	loop    func(ctx context.Context)
+	ctx     context.Context
+	cancel  context.CancelFunc
+	started atomic.Bool
+	done    chan struct{}
+}
+
+func newRunner(name string, loop func(ctx context.Context), logger log.Logger) *runner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &runner{
+		name:   name,
+		h:      log.NewHelper(logger),
+		loop:   loop,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+}
+
+// Start 阻塞运行循环，直到 ctx 取消或调用 Stop
+func (r *runner) Start(ctx context.Context) error {
+	if !r.started.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer close(r.done)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(r.ctx, cancel)
+	defer stop()
+	if ctx.Err() != nil {
+		return nil
+	}
+	r.loop(ctx)
+	return nil
+}
+
+// Stop 通知循环退出并等待其返回，ctx 到期时不再等待
+func (r *runner) Stop(ctx context.Context) error {
+	r.cancel()
+	if !r.started.Load() {
+		return nil
+	}
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	r.h.Infof("[%s] stopped", r.name)
+	return nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	pb "github.com/TiktokCommence/userService/api/user/v1"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// AdminTokenHeader 管理员接口通过该请求头携带管理员凭证
+const AdminTokenHeader = "x-admin-token"
+
+// adminOperations 只有持有管理员凭证的调用方才能访问的接口
+var adminOperations = map[string]bool{
+	pb.UserService_UnlockAccount_FullMethodName: true,
+}
+
+// Admin 校验管理员接口的请求头 x-admin-token，token 为空时拒绝所有管理员接口调用
+func Admin(token string) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if tr, ok := transport.FromServerContext(ctx); ok && adminOperations[tr.Operation()] {
+				if !AdminTokenValid(token, tr.RequestHeader().Get(AdminTokenHeader)) {
+					return nil, kerrors.Forbidden(ReasonAdminRequired, ErrAdminRequired.Error()).WithCause(ErrAdminRequired)
+				}
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// AdminTokenValid 以固定时间比较调用方携带的凭证，未配置凭证时总是返回 false
+func AdminTokenValid(token, got string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(got)) == 1
+}
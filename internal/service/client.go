@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"github.com/TiktokCommence/userService/internal/model"
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc/peer"
+)
+
+// clientInfo 从请求上下文中提取客户端信息，只有直连地址属于受信代理时才使用转发头中的来源地址
+func (s *UserServiceService) clientInfo(ctx context.Context, device string) model.ClientInfo {
+	info := model.ClientInfo{Device: device}
+	var forwardedFor, realIP string
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		header := tr.RequestHeader()
+		info.UserAgent = header.Get("user-agent")
+		forwardedFor = header.Get("x-forwarded-for")
+		realIP = header.Get("x-real-ip")
+	}
+	info.IP = s.clients.Resolve(remoteAddr(ctx), forwardedFor, realIP)
+	if info.Device == "" {
+		info.Device = info.UserAgent
+	}
+	return info
+}
+
+// remoteAddr 返回连接的对端地址
+func remoteAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	if r, ok := khttp.RequestFromServerContext(ctx); ok {
+		return r.RemoteAddr
+	}
+	return ""
+}
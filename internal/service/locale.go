@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"github.com/TiktokCommence/userService/internal/foundation/mailtpl"
+	"github.com/TiktokCommence/userService/internal/model"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// LocaleHeader 调用方显式指定语言的请求头，优先于 Accept-Language
+const LocaleHeader = "x-md-global-locale"
+
+// Locale 从请求头中取出语言写入上下文，用户设置了偏好语言时发送邮件以用户设置为准
+func Locale() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				header := tr.RequestHeader()
+				locale := mailtpl.Normalize(header.Get(LocaleHeader))
+				if locale == "" {
+					locale = mailtpl.ParseAcceptLanguage(header.Get("accept-language"))
+				}
+				if locale != "" {
+					ctx = model.WithLocale(ctx, locale)
+				}
+			}
+			return handler(ctx, req)
+		}
+	}
+}
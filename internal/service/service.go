@@ -3,38 +3,189 @@ package service
 import (
 	"context"
 	"errors"
+	"github.com/TiktokCommence/userService/internal/errcode"
 	"github.com/TiktokCommence/userService/internal/model"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
 	"github.com/google/wire"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // ProviderSet is service providers.
 var ProviderSet = wire.NewSet(NewUserServiceService)
 
 type UserHandler interface {
+	// 邮箱不合法时返回 errcode.EmailInvalid
+	CanonicalEmail(email string) (string, error)
 	CreateUser(ctx context.Context, email string, password string) (uint64, error)
-	VerifyCode(ctx context.Context, email string, code string) bool
-	SendVerifyCode(ctx context.Context, email string) (string, error)
+	VerifyCode(ctx context.Context, email string, code string) error
+	SendVerifyCode(ctx context.Context, email string) (time.Duration, error)
+	PeekVerifyCode(ctx context.Context, purpose string, email string) (string, error)
 	GetUserInfoByID(ctx context.Context, userID uint64) (model.User, error)
-	UpdateUserInfo(ctx context.Context, user model.User) error
+	// 用户不存在时返回 errcode.UserNotFound
+	UpdateProfile(ctx context.Context, userID uint64, p model.ProfileUpdate) error
 	CheckEmailExist(ctx context.Context, email string) bool
 	GetUserInfoByEmail(ctx context.Context, email string) (model.User, error)
-	Logout(ctx context.Context, userID uint64) error
+	VerifyPassword(ctx context.Context, email string, password string, ip string) (model.User, error)
+	SendLoginCode(ctx context.Context, email string) error
+	VerifyLoginCode(ctx context.Context, email string, code string, ip string) (model.User, error)
+	UnlockAccount(ctx context.Context, email string, ip string) error
+	SendResetCode(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, email string, code string, password string) (uint64, error)
+	ChangePassword(ctx context.Context, userID uint64, oldPassword string, newPassword string, ip string) error
+	CheckPasswordPolicy(email string, password string) []model.PasswordViolation
 	DeleteUser(ctx context.Context, userID uint64) error
+	ChangePhone(ctx context.Context, userID uint64, phone string) error
+	SendPhoneCode(ctx context.Context, userID uint64, phone string) (string, time.Duration, error)
+	VerifyPhone(ctx context.Context, userID uint64, phone string, code string) (string, error)
+	SendPhoneLoginCode(ctx context.Context, phone string) error
+	VerifyPhoneLoginCode(ctx context.Context, phone string, code string, ip string) (model.User, error)
+	VerifyPasswordByPhone(ctx context.Context, phone string, password string, ip string) (model.User, error)
+	RequestEmailChange(ctx context.Context, userID uint64, newEmail string, password string, ip string) (time.Duration, error)
+	ConfirmEmailChange(ctx context.Context, userID uint64, newEmail string, code string, client model.ClientInfo) error
+}
+
+type AuthHandler interface {
+	IssueTokens(ctx context.Context, user model.User, client model.ClientInfo) (model.TokenPair, error)
+	RefreshTokens(ctx context.Context, refreshToken string, client model.ClientInfo) (model.TokenPair, error)
+	VerifyAccessToken(ctx context.Context, accessToken string) (model.TokenClaims, error)
+	IntrospectToken(ctx context.Context, accessToken string) (model.TokenIntrospection, error)
+	GetJWKS(ctx context.Context) []model.JWK
+	Logout(ctx context.Context, userID uint64, accessToken string) error
+	ListSessions(ctx context.Context, userID uint64) ([]model.Session, error)
+	RevokeSession(ctx context.Context, userID uint64, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID uint64, exceptSessionID string) (int, error)
+}
+
+type TwoFactorHandler interface {
+	// 需要当前密码，密码错误次数过多时返回 *errcode.LockedError
+	Enroll(ctx context.Context, userID uint64, password string, ip string) (string, string, error)
+	Confirm(ctx context.Context, userID uint64, password string, code string, ip string) ([]string, error)
+	Disable(ctx context.Context, userID uint64, password string, code string, ip string) error
+	BeginLogin(ctx context.Context, user model.User, client model.ClientInfo) (string, bool, error)
+	CompleteLogin(ctx context.Context, challengeID string, code string) (model.User, model.ClientInfo, error)
+}
+
+// PublicIDCodec 对外的用户 ID 与数字 ID 互相转换，接口中出现的用户 ID 都是对外的形式，数字 ID 只在内部使用
+type PublicIDCodec interface {
+	Encode(id uint64) string
+	Decode(publicID string) (uint64, error)
+}
+
+// ClientIPResolver 根据连接的对端地址与转发头确定客户端地址，只信任受信代理添加的转发头
+type ClientIPResolver interface {
+	Resolve(remoteAddr, forwardedFor, realIP string) string
 }
 
 var (
-	ErrPasswordsDoNotMatch = errors.New("passwords do not equal confirm password")
-	ErrPasswordNotValid    = errors.New("password is invalid")
-	ErrEmailVerifyCode     = errors.New("email verify code is not valid")
-	ErrCreateUser          = errors.New("create user failed")
-	ErrUserAlreadyExists   = errors.New("user already exists")
-	ErrSendVerifyCode      = errors.New("send verify code failed")
-	ErrUserNotFound        = errors.New("user not found")
-	ErrGetUserInfo         = errors.New("get user info failed")
-	ErrUpdateUser          = errors.New("update user info failed")
-	ErrLogin               = errors.New("login failed")
-	ErrPasswordIncorrect   = errors.New("password incorrect")
-	ErrLogout              = errors.New("logout failed")
-	ErrDeleteUser          = errors.New("delete user failed")
-	ErrEmailExist          = errors.New("email already exists")
+	ErrPasswordsDoNotMatch  = errors.New("passwords do not equal confirm password")
+	ErrPasswordNotValid     = errors.New("password is invalid")
+	ErrEmailVerifyCode      = errors.New("email verify code is not valid")
+	ErrCreateUser           = errors.New("create user failed")
+	ErrUserAlreadyExists    = errors.New("user already exists")
+	ErrSendVerifyCode       = errors.New("send verify code failed")
+	ErrVerifyCodeCooldown   = errors.New("verify code was sent too recently")
+	ErrVerifyCodeQuota      = errors.New("daily verify code quota exceeded")
+	ErrVerifyCodeAttempts   = errors.New("verify code attempts exceeded, please request a new one")
+	ErrVerifyCodeNotExposed = errors.New("verify codes are not exposed")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrUserIDInvalid        = errors.New("user id is invalid")
+	ErrGetUserInfo          = errors.New("get user info failed")
+	ErrUpdateUser           = errors.New("update user info failed")
+	ErrLogin                = errors.New("login failed")
+	ErrPasswordIncorrect    = errors.New("password incorrect")
+	ErrLogout               = errors.New("logout failed")
+	ErrDeleteUser           = errors.New("delete user failed")
+	ErrEmailExist           = errors.New("email already exists")
+	ErrRefreshToken         = errors.New("refresh token is invalid or expired")
+	ErrIssueToken           = errors.New("issue token failed")
+	ErrAccessToken          = errors.New("access token is invalid or revoked")
+	ErrSessionNotFound      = errors.New("session not found")
+	ErrListSessions         = errors.New("list sessions failed")
+	ErrRevokeSession        = errors.New("revoke session failed")
+	ErrIntrospectToken      = errors.New("introspect token failed")
+	ErrResetPassword        = errors.New("reset password failed")
+	ErrChangePassword       = errors.New("change password failed")
+	ErrAccountLocked        = errors.New("account is temporarily locked")
+	ErrUnlockAccount        = errors.New("unlock account failed")
+	ErrAdminRequired        = errors.New("admin credential is required")
+	ErrTwoFactorNotEnabled  = errors.New("two factor is not enrolled")
+	ErrTwoFactorEnabled     = errors.New("two factor is already enabled")
+	ErrTwoFactorCode        = errors.New("two factor code is invalid")
+	ErrTwoFactor            = errors.New("two factor operation failed")
+	ErrLoginChallenge       = errors.New("login challenge is invalid or expired")
+	ErrPhoneInvalid         = errors.New("phone number is invalid")
+	ErrPhoneAlreadyUsed     = errors.New("phone number is already used by another user")
+	ErrPhoneVerifyCode      = errors.New("phone verify code is not valid")
+	ErrVerifyPhone          = errors.New("verify phone failed")
+	ErrChangeEmail          = errors.New("change email failed")
+	ErrEmailChangeNotFound  = errors.New("email change is not requested or expired")
+	ErrEmailInvalid         = errors.New("email is invalid")
 )
+
+const (
+	ReasonPasswordPolicy = "PASSWORD_POLICY_VIOLATION"
+	ReasonAccountLocked  = "ACCOUNT_LOCKED"
+	ReasonCodeCooldown   = "VERIFY_CODE_COOLDOWN"
+	ReasonAdminRequired  = "ADMIN_REQUIRED"
+)
+
+// passwordPolicyError 把不满足的密码规则放进错误的 metadata，
+// "violations" 为逗号分隔的规则代码，每条规则代码对应一条可展示的说明
+func passwordPolicyError(violations []model.PasswordViolation) error {
+	codes := make([]string, 0, len(violations))
+	md := make(map[string]string, len(violations)+1)
+	for _, v := range violations {
+		codes = append(codes, v.Code)
+		md[v.Code] = v.Message
+	}
+	md["violations"] = strings.Join(codes, ",")
+	return kerrors.BadRequest(ReasonPasswordPolicy, ErrPasswordNotValid.Error()).
+		WithMetadata(md).
+		WithCause(ErrPasswordNotValid)
+}
+
+// accountLockedError 在 metadata 的 "retry_after" 中返回剩余的锁定秒数
+func accountLockedError(retryAfter time.Duration) error {
+	return kerrors.New(http.StatusTooManyRequests, ReasonAccountLocked, ErrAccountLocked.Error()).
+		WithMetadata(map[string]string{"retry_after": strconv.FormatInt(ceilSeconds(retryAfter), 10)}).
+		WithCause(ErrAccountLocked)
+}
+
+func isVerifyCodeError(err error) bool {
+	return errors.Is(err, errcode.VerifyCodeInvalid) || errors.Is(err, errcode.VerifyCodeAttemptsExceeded) ||
+		errors.Is(err, errcode.VerifyCodeCooldown) || errors.Is(err, errcode.VerifyCodeQuotaExceeded)
+}
+
+// verifyCodeError 把发送、校验验证码的错误转换为对外的错误，其他错误返回 fallback，
+// 发送过于频繁时在 metadata 的 "retry_after" 中返回还需等待的秒数
+func verifyCodeError(err error, fallback error) error {
+	var cooldown *errcode.CooldownError
+	switch {
+	case errors.As(err, &cooldown):
+		return kerrors.New(http.StatusTooManyRequests, ReasonCodeCooldown, ErrVerifyCodeCooldown.Error()).
+			WithMetadata(map[string]string{"retry_after": strconv.FormatInt(ceilSeconds(cooldown.RetryAfter), 10)}).
+			WithCause(ErrVerifyCodeCooldown)
+	case errors.Is(err, errcode.VerifyCodeQuotaExceeded):
+		return ErrVerifyCodeQuota
+	case errors.Is(err, errcode.VerifyCodeAttemptsExceeded):
+		return ErrVerifyCodeAttempts
+	case errors.Is(err, errcode.VerifyCodeInvalid):
+		return ErrEmailVerifyCode
+	}
+	return fallback
+}
+
+// phoneCodeError 与 verifyCodeError 相同，只是验证码错误时返回短信验证码对应的错误
+func phoneCodeError(err error, fallback error) error {
+	if errors.Is(err, errcode.VerifyCodeInvalid) {
+		return ErrPhoneVerifyCode
+	}
+	return verifyCodeError(err, fallback)
+}
+
+func ceilSeconds(d time.Duration) int64 {
+	return int64((d + time.Second - 1) / time.Second)
+}
@@ -5,31 +5,79 @@ import (
 	"errors"
 	pb "github.com/TiktokCommence/userService/api/user/v1"
 	"github.com/TiktokCommence/userService/internal/errcode"
-	"github.com/TiktokCommence/userService/internal/tool"
+	"github.com/TiktokCommence/userService/internal/foundation/mailtpl"
+	"github.com/TiktokCommence/userService/internal/model"
 )
 
 type UserServiceService struct {
 	pb.UnimplementedUserServiceServer
-	userHandler UserHandler
+	userHandler      UserHandler
+	authHandler      AuthHandler
+	twoFactorHandler TwoFactorHandler
+	ids              PublicIDCodec
+	clients          ClientIPResolver
 }
 
-func NewUserServiceService(userHandler UserHandler) *UserServiceService {
+func NewUserServiceService(userHandler UserHandler, authHandler AuthHandler, twoFactorHandler TwoFactorHandler, ids PublicIDCodec, clients ClientIPResolver) *UserServiceService {
 	return &UserServiceService{
-		userHandler: userHandler,
+		userHandler:      userHandler,
+		authHandler:      authHandler,
+		twoFactorHandler: twoFactorHandler,
+		ids:              ids,
+		clients:          clients,
 	}
 }
 
+// userID 把请求中的对外用户 ID 转换为数字 ID
+func (s *UserServiceService) userID(publicID string) (uint64, error) {
+	id, err := s.ids.Decode(publicID)
+	if err != nil {
+		return 0, ErrUserIDInvalid
+	}
+	return id, nil
+}
+
+// publicID 把数字 ID 转换为对外的用户 ID，没有用户时返回空字符串
+func (s *UserServiceService) publicID(id uint64) string {
+	if id == 0 {
+		return ""
+	}
+	return s.ids.Encode(id)
+}
+
+// tokenUserID 校验访问令牌并返回其所属的用户，请求中的对外用户 ID 不为空时需要与令牌一致；
+// 令牌以外的错误返回 fallback
+func (s *UserServiceService) tokenUserID(ctx context.Context, accessToken string, publicID string, fallback error) (uint64, error) {
+	claims, err := s.authHandler.VerifyAccessToken(ctx, accessToken)
+	if errors.Is(err, errcode.TokenInvalid) || errors.Is(err, errcode.TokenExpired) || errors.Is(err, errcode.SessionRevoked) {
+		return 0, ErrAccessToken
+	}
+	if err != nil {
+		return 0, fallback
+	}
+	if publicID != "" {
+		if id, err := s.userID(publicID); err != nil || id != claims.UserID {
+			return 0, ErrAccessToken
+		}
+	}
+	return claims.UserID, nil
+}
+
 func (s *UserServiceService) Register(ctx context.Context, req *pb.RegisterReq) (*pb.RegisterResp, error) {
+	email, err := s.userHandler.CanonicalEmail(req.GetEmail())
+	if err != nil {
+		return &pb.RegisterResp{}, ErrEmailInvalid
+	}
 	if req.GetPassword() != req.GetConfirmPassword() {
 		return &pb.RegisterResp{}, ErrPasswordsDoNotMatch
 	}
-	if !tool.CheckPassword(req.GetPassword()) {
-		return &pb.RegisterResp{}, ErrPasswordNotValid
+	if violations := s.userHandler.CheckPasswordPolicy(email, req.GetPassword()); len(violations) > 0 {
+		return &pb.RegisterResp{}, passwordPolicyError(violations)
 	}
-	if !s.userHandler.VerifyCode(ctx, req.GetEmail(), req.GetVerifyCode()) {
-		return &pb.RegisterResp{}, ErrEmailVerifyCode
+	if err := s.userHandler.VerifyCode(ctx, email, req.GetVerifyCode()); err != nil {
+		return &pb.RegisterResp{}, verifyCodeError(err, ErrEmailVerifyCode)
 	}
-	userID, err := s.userHandler.CreateUser(ctx, req.GetEmail(), req.GetPassword())
+	userID, err := s.userHandler.CreateUser(ctx, email, req.GetPassword())
 	if errors.Is(err, errcode.UserAlreadyExists) {
 		return &pb.RegisterResp{}, ErrUserAlreadyExists
 	}
@@ -37,36 +85,250 @@ func (s *UserServiceService) Register(ctx context.Context, req *pb.RegisterReq)
 		return &pb.RegisterResp{}, ErrCreateUser
 	}
 	return &pb.RegisterResp{
-		UserId: userID,
+		UserId: s.publicID(userID),
 	}, nil
 }
 func (s *UserServiceService) Login(ctx context.Context, req *pb.LoginReq) (*pb.LoginResp, error) {
-	user, err := s.userHandler.GetUserInfoByEmail(ctx, req.GetEmail())
+	client := s.clientInfo(ctx, req.GetDevice())
+	var user model.User
+	var err error
+	if req.GetEmail() == "" && req.GetPhone() != "" {
+		user, err = s.userHandler.VerifyPasswordByPhone(ctx, req.GetPhone(), req.GetPassword(), client.IP)
+	} else {
+		var email string
+		if email, err = s.userHandler.CanonicalEmail(req.GetEmail()); err != nil {
+			return &pb.LoginResp{}, ErrEmailInvalid
+		}
+		user, err = s.userHandler.VerifyPassword(ctx, email, req.GetPassword(), client.IP)
+	}
+	if errors.Is(err, errcode.PhoneInvalid) {
+		return &pb.LoginResp{}, ErrPhoneInvalid
+	}
+	var locked *errcode.LockedError
+	if errors.As(err, &locked) {
+		return &pb.LoginResp{}, accountLockedError(locked.RetryAfter)
+	}
 	if errors.Is(err, errcode.UserNotFound) {
 		return &pb.LoginResp{}, ErrUserNotFound
 	}
+	if errors.Is(err, errcode.PasswordIncorrect) {
+		return &pb.LoginResp{}, ErrPasswordIncorrect
+	}
 	if err != nil {
 		return &pb.LoginResp{}, ErrLogin
 	}
-	if user.Password != req.GetPassword() {
-		return &pb.LoginResp{}, ErrPasswordIncorrect
+	return s.login(ctx, user, client)
+}
+func (s *UserServiceService) SendLoginCode(ctx context.Context, req *pb.SendLoginCodeReq) (*pb.SendLoginCodeResp, error) {
+	var err error
+	if req.GetEmail() == "" && req.GetPhone() != "" {
+		err = s.userHandler.SendPhoneLoginCode(ctx, req.GetPhone())
+	} else {
+		var email string
+		if email, err = s.userHandler.CanonicalEmail(req.GetEmail()); err != nil {
+			return &pb.SendLoginCodeResp{Success: false}, ErrEmailInvalid
+		}
+		err = s.userHandler.SendLoginCode(ctx, email)
+	}
+	if errors.Is(err, errcode.PhoneInvalid) {
+		return &pb.SendLoginCodeResp{Success: false}, ErrPhoneInvalid
+	}
+	if err != nil {
+		return &pb.SendLoginCodeResp{Success: false}, verifyCodeError(err, ErrSendVerifyCode)
+	}
+	return &pb.SendLoginCodeResp{Success: true}, nil
+}
+func (s *UserServiceService) LoginWithEmailCode(ctx context.Context, req *pb.LoginWithEmailCodeReq) (*pb.LoginResp, error) {
+	email, err := s.userHandler.CanonicalEmail(req.GetEmail())
+	if err != nil {
+		return &pb.LoginResp{}, ErrEmailInvalid
+	}
+	client := s.clientInfo(ctx, req.GetDevice())
+	user, err := s.userHandler.VerifyLoginCode(ctx, email, req.GetVerifyCode(), client.IP)
+	var locked *errcode.LockedError
+	if errors.As(err, &locked) {
+		return &pb.LoginResp{}, accountLockedError(locked.RetryAfter)
+	}
+	if isVerifyCodeError(err) {
+		return &pb.LoginResp{}, verifyCodeError(err, ErrEmailVerifyCode)
+	}
+	if errors.Is(err, errcode.UserNotFound) {
+		return &pb.LoginResp{}, ErrUserNotFound
+	}
+	if err != nil {
+		return &pb.LoginResp{}, ErrLogin
+	}
+	return s.login(ctx, user, client)
+}
+func (s *UserServiceService) LoginWithPhoneCode(ctx context.Context, req *pb.LoginWithPhoneCodeReq) (*pb.LoginResp, error) {
+	client := s.clientInfo(ctx, req.GetDevice())
+	user, err := s.userHandler.VerifyPhoneLoginCode(ctx, req.GetPhone(), req.GetVerifyCode(), client.IP)
+	if errors.Is(err, errcode.PhoneInvalid) {
+		return &pb.LoginResp{}, ErrPhoneInvalid
+	}
+	var locked *errcode.LockedError
+	if errors.As(err, &locked) {
+		return &pb.LoginResp{}, accountLockedError(locked.RetryAfter)
+	}
+	if isVerifyCodeError(err) {
+		return &pb.LoginResp{}, phoneCodeError(err, ErrLogin)
+	}
+	if errors.Is(err, errcode.UserNotFound) {
+		return &pb.LoginResp{}, ErrUserNotFound
+	}
+	if err != nil {
+		return &pb.LoginResp{}, ErrLogin
+	}
+	return s.login(ctx, user, client)
+}
+
+// login 第一步验证通过后签发令牌，启用了二次验证的账号先返回挑战，由 VerifySecondFactor 完成登录后再签发令牌
+func (s *UserServiceService) login(ctx context.Context, user model.User, client model.ClientInfo) (*pb.LoginResp, error) {
+	challengeID, required, err := s.twoFactorHandler.BeginLogin(ctx, user, client)
+	if err != nil {
+		return &pb.LoginResp{}, ErrLogin
+	}
+	if required {
+		return &pb.LoginResp{
+			UserId:      s.publicID(user.ID),
+			MfaRequired: true,
+			ChallengeId: challengeID,
+		}, nil
+	}
+	pair, err := s.authHandler.IssueTokens(ctx, user, client)
+	if err != nil {
+		return &pb.LoginResp{}, ErrIssueToken
 	}
 	return &pb.LoginResp{
-		UserId: user.ID,
+		UserId:           s.publicID(user.ID),
+		SessionId:        pair.SessionID,
+		AccessToken:      pair.AccessToken,
+		RefreshToken:     pair.RefreshToken,
+		AccessExpiresAt:  pair.AccessExpiresAt.Unix(),
+		RefreshExpiresAt: pair.RefreshExpiresAt.Unix(),
 	}, nil
 }
+func (s *UserServiceService) RefreshToken(ctx context.Context, req *pb.RefreshTokenReq) (*pb.RefreshTokenResp, error) {
+	pair, err := s.authHandler.RefreshTokens(ctx, req.GetRefreshToken(), s.clientInfo(ctx, ""))
+	if errors.Is(err, errcode.TokenInvalid) || errors.Is(err, errcode.TokenExpired) || errors.Is(err, errcode.SessionRevoked) {
+		return &pb.RefreshTokenResp{}, ErrRefreshToken
+	}
+	if err != nil {
+		return &pb.RefreshTokenResp{}, ErrIssueToken
+	}
+	return &pb.RefreshTokenResp{
+		UserId:           s.publicID(pair.UserID),
+		SessionId:        pair.SessionID,
+		AccessToken:      pair.AccessToken,
+		RefreshToken:     pair.RefreshToken,
+		AccessExpiresAt:  pair.AccessExpiresAt.Unix(),
+		RefreshExpiresAt: pair.RefreshExpiresAt.Unix(),
+	}, nil
+}
+func (s *UserServiceService) IntrospectToken(ctx context.Context, req *pb.IntrospectTokenReq) (*pb.IntrospectTokenResp, error) {
+	result, err := s.authHandler.IntrospectToken(ctx, req.GetToken())
+	if err != nil {
+		return &pb.IntrospectTokenResp{}, ErrIntrospectToken
+	}
+	if !result.Active {
+		return &pb.IntrospectTokenResp{Active: false, State: result.State}, nil
+	}
+	claims := result.Claims
+	return &pb.IntrospectTokenResp{
+		Active:    true,
+		State:     result.State,
+		UserId:    s.publicID(claims.UserID),
+		SessionId: claims.SessionID,
+		Roles:     claims.Roles,
+		TokenId:   claims.ID,
+		IssuedAt:  claims.IssuedAt.Unix(),
+		ExpiresAt: claims.ExpiresAt.Unix(),
+	}, nil
+}
+func (s *UserServiceService) GetJWKS(ctx context.Context, req *pb.GetJWKSReq) (*pb.GetJWKSResp, error) {
+	jwks := s.authHandler.GetJWKS(ctx)
+	resp := &pb.GetJWKSResp{Keys: make([]*pb.JWK, 0, len(jwks))}
+	for _, k := range jwks {
+		resp.Keys = append(resp.Keys, &pb.JWK{
+			Kty: k.Kty,
+			Crv: k.Crv,
+			X:   k.X,
+			N:   k.N,
+			E:   k.E,
+			Kid: k.Kid,
+			Alg: k.Alg,
+			Use: k.Use,
+		})
+	}
+	return resp, nil
+}
 func (s *UserServiceService) Logout(ctx context.Context, req *pb.LogoutReq) (*pb.LogoutResp, error) {
-	err := s.userHandler.Logout(ctx, req.GetUserId())
-	if errors.Is(err, errcode.UserNotFound) {
-		return &pb.LogoutResp{Success: false}, ErrUserNotFound
+	userID, err := s.userID(req.GetUserId())
+	if err != nil {
+		return &pb.LogoutResp{Success: false}, err
+	}
+	err = s.authHandler.Logout(ctx, userID, req.GetAccessToken())
+	if errors.Is(err, errcode.TokenInvalid) || errors.Is(err, errcode.TokenExpired) || errors.Is(err, errcode.SessionRevoked) {
+		return &pb.LogoutResp{Success: false}, ErrAccessToken
 	}
 	if err != nil {
 		return &pb.LogoutResp{Success: false}, ErrLogout
 	}
 	return &pb.LogoutResp{Success: true}, nil
 }
+func (s *UserServiceService) ListSessions(ctx context.Context, req *pb.ListSessionsReq) (*pb.ListSessionsResp, error) {
+	userID, err := s.tokenUserID(ctx, req.GetAccessToken(), req.GetUserId(), ErrListSessions)
+	if err != nil {
+		return &pb.ListSessionsResp{}, err
+	}
+	sessions, err := s.authHandler.ListSessions(ctx, userID)
+	if err != nil {
+		return &pb.ListSessionsResp{}, ErrListSessions
+	}
+	resp := &pb.ListSessionsResp{Sessions: make([]*pb.SessionInfo, 0, len(sessions))}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, &pb.SessionInfo{
+			SessionId:  session.ID,
+			Device:     session.Device,
+			Ip:         session.IP,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt.Unix(),
+			LastSeenAt: session.LastSeenAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+func (s *UserServiceService) RevokeSession(ctx context.Context, req *pb.RevokeSessionReq) (*pb.RevokeSessionResp, error) {
+	userID, err := s.tokenUserID(ctx, req.GetAccessToken(), req.GetUserId(), ErrRevokeSession)
+	if err != nil {
+		return &pb.RevokeSessionResp{Success: false}, err
+	}
+	err = s.authHandler.RevokeSession(ctx, userID, req.GetSessionId())
+	if errors.Is(err, errcode.SessionRevoked) {
+		return &pb.RevokeSessionResp{Success: false}, ErrSessionNotFound
+	}
+	if err != nil {
+		return &pb.RevokeSessionResp{Success: false}, ErrRevokeSession
+	}
+	return &pb.RevokeSessionResp{Success: true}, nil
+}
+func (s *UserServiceService) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsReq) (*pb.RevokeAllSessionsResp, error) {
+	userID, err := s.tokenUserID(ctx, req.GetAccessToken(), req.GetUserId(), ErrRevokeSession)
+	if err != nil {
+		return &pb.RevokeAllSessionsResp{}, err
+	}
+	revoked, err := s.authHandler.RevokeAllSessions(ctx, userID, req.GetExceptSessionId())
+	if err != nil {
+		return &pb.RevokeAllSessionsResp{Revoked: int64(revoked)}, ErrRevokeSession
+	}
+	return &pb.RevokeAllSessionsResp{Revoked: int64(revoked)}, nil
+}
 func (s *UserServiceService) DeleteUser(ctx context.Context, req *pb.DeleteReq) (*pb.DeleteResp, error) {
-	err := s.userHandler.DeleteUser(ctx, req.GetUserId())
+	userID, err := s.userID(req.GetUserId())
+	if err != nil {
+		return &pb.DeleteResp{Success: false}, err
+	}
+	err = s.userHandler.DeleteUser(ctx, userID)
 	if errors.Is(err, errcode.UserNotFound) {
 		return &pb.DeleteResp{Success: false}, ErrUserNotFound
 	}
@@ -76,40 +338,50 @@ func (s *UserServiceService) DeleteUser(ctx context.Context, req *pb.DeleteReq)
 	return &pb.DeleteResp{Success: true}, nil
 }
 func (s *UserServiceService) UpdateUser(ctx context.Context, req *pb.UpdateReq) (*pb.UpdateResp, error) {
-	user, err := s.userHandler.GetUserInfoByID(ctx, req.GetId())
-	if errors.Is(err, errcode.UserNotFound) {
-		return &pb.UpdateResp{Success: false}, ErrUserNotFound
-	}
+	userID, err := s.userID(req.GetId())
 	if err != nil {
-		return &pb.UpdateResp{Success: false}, ErrUpdateUser
+		return &pb.UpdateResp{Success: false}, err
 	}
-	if req.Name != nil {
-		user.Name = req.Name
+	// 只修改请求中设置了的字段，不读取再整体写回，避免覆盖并发修改的密码、邮箱
+	profile := model.ProfileUpdate{
+		Name:  req.Name,
+		Age:   req.Age,
+		Addr1: req.Addr1,
+		Addr2: req.Addr2,
 	}
-	if req.Age != nil {
-		user.Age = req.Age
+	if req.Locale != nil {
+		locale := mailtpl.Normalize(req.GetLocale())
+		profile.Locale = &locale
 	}
-	if req.Addr1 != nil {
-		user.Addr1 = req.Addr1
-	}
-	if req.Addr2 != nil {
-		user.Addr2 = req.Addr2
-	}
-	if req.Phone != nil {
-		user.Phone = req.Phone
+	err = s.userHandler.UpdateProfile(ctx, userID, profile)
+	if errors.Is(err, errcode.UserNotFound) {
+		return &pb.UpdateResp{Success: false}, ErrUserNotFound
 	}
-	err = s.userHandler.UpdateUserInfo(ctx, user)
 	if err != nil {
 		return &pb.UpdateResp{
 			Success: false,
 		}, ErrUpdateUser
 	}
+	// 手机号单独更新，号码变化后需要重新验证
+	if req.Phone != nil {
+		err = s.userHandler.ChangePhone(ctx, userID, req.GetPhone())
+		if errors.Is(err, errcode.PhoneInvalid) {
+			return &pb.UpdateResp{Success: false}, ErrPhoneInvalid
+		}
+		if err != nil {
+			return &pb.UpdateResp{Success: false}, ErrUpdateUser
+		}
+	}
 	return &pb.UpdateResp{
 		Success: true,
 	}, nil
 }
 func (s *UserServiceService) GetUserInfo(ctx context.Context, req *pb.GetReq) (*pb.GetResp, error) {
-	user, err := s.userHandler.GetUserInfoByID(ctx, req.GetUserId())
+	userID, err := s.userID(req.GetUserId())
+	if err != nil {
+		return &pb.GetResp{}, err
+	}
+	user, err := s.userHandler.GetUserInfoByID(ctx, userID)
 	if errors.Is(err, errcode.UserNotFound) {
 		return &pb.GetResp{}, ErrUserNotFound
 	}
@@ -117,23 +389,338 @@ func (s *UserServiceService) GetUserInfo(ctx context.Context, req *pb.GetReq) (*
 		return &pb.GetResp{}, ErrGetUserInfo
 	}
 	return &pb.GetResp{
-		Name:  user.Name,
-		Email: user.Email,
-		Phone: user.Phone,
-		Age:   user.Age,
-		Addr1: user.Addr1,
-		Addr2: user.Addr2,
+		Name:          user.Name,
+		Email:         user.Email,
+		Phone:         user.Phone,
+		Age:           user.Age,
+		Addr1:         user.Addr1,
+		Addr2:         user.Addr2,
+		Locale:        user.Locale,
+		PhoneVerified: user.PhoneVerifiedAt != nil,
 	}, nil
 }
 func (s *UserServiceService) SendVerifyCode(ctx context.Context, req *pb.SendReq) (*pb.SendResp, error) {
-	if s.userHandler.CheckEmailExist(ctx, req.GetEmail()) {
+	email, err := s.userHandler.CanonicalEmail(req.GetEmail())
+	if err != nil {
+		return &pb.SendResp{}, ErrEmailInvalid
+	}
+	if s.userHandler.CheckEmailExist(ctx, email) {
 		return &pb.SendResp{}, ErrEmailExist
 	}
-	code, err := s.userHandler.SendVerifyCode(ctx, req.GetEmail())
+	cooldown, err := s.userHandler.SendVerifyCode(ctx, email)
 	if err != nil {
-		return &pb.SendResp{}, ErrSendVerifyCode
+		return &pb.SendResp{}, verifyCodeError(err, ErrSendVerifyCode)
 	}
 	return &pb.SendResp{
-		Code: code,
+		Sent:            true,
+		CooldownSeconds: ceilSeconds(cooldown),
+	}, nil
+}
+
+// PeekVerifyCode 供调试接口读取验证码，不对外暴露为 RPC，只有配置开启 exposeCodes 时可用
+func (s *UserServiceService) PeekVerifyCode(ctx context.Context, purpose string, email string) (string, error) {
+	// 短信验证码以 E.164 号码为目标，不是邮箱时原样查询
+	if canonical, err := s.userHandler.CanonicalEmail(email); err == nil {
+		email = canonical
+	}
+	code, err := s.userHandler.PeekVerifyCode(ctx, purpose, email)
+	if errors.Is(err, errcode.VerifyCodeNotExposed) {
+		return "", ErrVerifyCodeNotExposed
+	}
+	if errors.Is(err, errcode.VerifyCodeInvalid) {
+		return "", ErrEmailVerifyCode
+	}
+	return code, err
+}
+func (s *UserServiceService) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetReq) (*pb.RequestPasswordResetResp, error) {
+	email, err := s.userHandler.CanonicalEmail(req.GetEmail())
+	if err != nil {
+		return &pb.RequestPasswordResetResp{Success: false}, ErrEmailInvalid
+	}
+	err = s.userHandler.SendResetCode(ctx, email)
+	if err != nil {
+		return &pb.RequestPasswordResetResp{Success: false}, verifyCodeError(err, ErrSendVerifyCode)
+	}
+	return &pb.RequestPasswordResetResp{Success: true}, nil
+}
+func (s *UserServiceService) ResetPassword(ctx context.Context, req *pb.ResetPasswordReq) (*pb.ResetPasswordResp, error) {
+	email, err := s.userHandler.CanonicalEmail(req.GetEmail())
+	if err != nil {
+		return &pb.ResetPasswordResp{Success: false}, ErrEmailInvalid
+	}
+	if req.GetPassword() != req.GetConfirmPassword() {
+		return &pb.ResetPasswordResp{Success: false}, ErrPasswordsDoNotMatch
+	}
+	if violations := s.userHandler.CheckPasswordPolicy(email, req.GetPassword()); len(violations) > 0 {
+		return &pb.ResetPasswordResp{Success: false}, passwordPolicyError(violations)
+	}
+	userID, err := s.userHandler.ResetPassword(ctx, email, req.GetVerifyCode(), req.GetPassword())
+	if isVerifyCodeError(err) {
+		return &pb.ResetPasswordResp{Success: false}, verifyCodeError(err, ErrEmailVerifyCode)
+	}
+	if errors.Is(err, errcode.UserNotFound) {
+		return &pb.ResetPasswordResp{Success: false}, ErrUserNotFound
+	}
+	if err != nil {
+		return &pb.ResetPasswordResp{Success: false}, ErrResetPassword
+	}
+	// 密码重置后之前登录的会话全部失效
+	if _, err = s.authHandler.RevokeAllSessions(ctx, userID, ""); err != nil {
+		return &pb.ResetPasswordResp{Success: false}, ErrRevokeSession
+	}
+	return &pb.ResetPasswordResp{Success: true}, nil
+}
+func (s *UserServiceService) ChangePassword(ctx context.Context, req *pb.ChangePasswordReq) (*pb.ChangePasswordResp, error) {
+	userID, err := s.userID(req.GetUserId())
+	if err != nil {
+		return &pb.ChangePasswordResp{Success: false}, err
+	}
+	user, err := s.userHandler.GetUserInfoByID(ctx, userID)
+	if errors.Is(err, errcode.UserNotFound) {
+		return &pb.ChangePasswordResp{Success: false}, ErrUserNotFound
+	}
+	if err != nil {
+		return &pb.ChangePasswordResp{Success: false}, ErrChangePassword
+	}
+	if violations := s.userHandler.CheckPasswordPolicy(user.Email, req.GetNewPassword()); len(violations) > 0 {
+		return &pb.ChangePasswordResp{Success: false}, passwordPolicyError(violations)
+	}
+	err = s.userHandler.ChangePassword(ctx, userID, req.GetOldPassword(), req.GetNewPassword(), s.clientInfo(ctx, "").IP)
+	var locked *errcode.LockedError
+	if errors.As(err, &locked) {
+		return &pb.ChangePasswordResp{Success: false}, accountLockedError(locked.RetryAfter)
+	}
+	if errors.Is(err, errcode.UserNotFound) {
+		return &pb.ChangePasswordResp{Success: false}, ErrUserNotFound
+	}
+	if errors.Is(err, errcode.PasswordIncorrect) {
+		return &pb.ChangePasswordResp{Success: false}, ErrPasswordIncorrect
+	}
+	if err != nil {
+		return &pb.ChangePasswordResp{Success: false}, ErrChangePassword
+	}
+	if req.GetRevokeOtherSessions() {
+		if _, err = s.authHandler.RevokeAllSessions(ctx, userID, req.GetCurrentSessionId()); err != nil {
+			return &pb.ChangePasswordResp{Success: false}, ErrRevokeSession
+		}
+	}
+	return &pb.ChangePasswordResp{Success: true}, nil
+}
+func (s *UserServiceService) UnlockAccount(ctx context.Context, req *pb.UnlockAccountReq) (*pb.UnlockAccountResp, error) {
+	email, err := s.userHandler.CanonicalEmail(req.GetEmail())
+	if err != nil {
+		return &pb.UnlockAccountResp{Success: false}, ErrEmailInvalid
+	}
+	if err := s.userHandler.UnlockAccount(ctx, email, req.GetIp()); err != nil {
+		return &pb.UnlockAccountResp{Success: false}, ErrUnlockAccount
+	}
+	return &pb.UnlockAccountResp{Success: true}, nil
+}
+func (s *UserServiceService) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPReq) (*pb.EnrollTOTPResp, error) {
+	userID, err := s.userID(req.GetUserId())
+	if err != nil {
+		return &pb.EnrollTOTPResp{}, err
+	}
+	secret, uri, err := s.twoFactorHandler.Enroll(ctx, userID, req.GetPassword(), s.clientInfo(ctx, "").IP)
+	var locked *errcode.LockedError
+	if errors.As(err, &locked) {
+		return &pb.EnrollTOTPResp{}, accountLockedError(locked.RetryAfter)
+	}
+	if errors.Is(err, errcode.UserNotFound) {
+		return &pb.EnrollTOTPResp{}, ErrUserNotFound
+	}
+	if errors.Is(err, errcode.PasswordIncorrect) {
+		return &pb.EnrollTOTPResp{}, ErrPasswordIncorrect
+	}
+	if errors.Is(err, errcode.TwoFactorAlreadyEnabled) {
+		return &pb.EnrollTOTPResp{}, ErrTwoFactorEnabled
+	}
+	if err != nil {
+		return &pb.EnrollTOTPResp{}, ErrTwoFactor
+	}
+	return &pb.EnrollTOTPResp{
+		Secret:     secret,
+		OtpauthUri: uri,
 	}, nil
 }
+func (s *UserServiceService) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPReq) (*pb.ConfirmTOTPResp, error) {
+	userID, err := s.userID(req.GetUserId())
+	if err != nil {
+		return &pb.ConfirmTOTPResp{}, err
+	}
+	codes, err := s.twoFactorHandler.Confirm(ctx, userID, req.GetPassword(), req.GetCode(), s.clientInfo(ctx, "").IP)
+	var locked *errcode.LockedError
+	if errors.As(err, &locked) {
+		return &pb.ConfirmTOTPResp{}, accountLockedError(locked.RetryAfter)
+	}
+	if errors.Is(err, errcode.UserNotFound) {
+		return &pb.ConfirmTOTPResp{}, ErrUserNotFound
+	}
+	if errors.Is(err, errcode.PasswordIncorrect) {
+		return &pb.ConfirmTOTPResp{}, ErrPasswordIncorrect
+	}
+	if errors.Is(err, errcode.TwoFactorNotEnrolled) {
+		return &pb.ConfirmTOTPResp{}, ErrTwoFactorNotEnabled
+	}
+	if errors.Is(err, errcode.TwoFactorAlreadyEnabled) {
+		return &pb.ConfirmTOTPResp{}, ErrTwoFactorEnabled
+	}
+	if errors.Is(err, errcode.TwoFactorCodeInvalid) {
+		return &pb.ConfirmTOTPResp{}, ErrTwoFactorCode
+	}
+	if err != nil {
+		return &pb.ConfirmTOTPResp{}, ErrTwoFactor
+	}
+	return &pb.ConfirmTOTPResp{RecoveryCodes: codes}, nil
+}
+func (s *UserServiceService) DisableTOTP(ctx context.Context, req *pb.DisableTOTPReq) (*pb.DisableTOTPResp, error) {
+	userID, err := s.userID(req.GetUserId())
+	if err != nil {
+		return &pb.DisableTOTPResp{Success: false}, err
+	}
+	err = s.twoFactorHandler.Disable(ctx, userID, req.GetPassword(), req.GetCode(), s.clientInfo(ctx, "").IP)
+	var locked *errcode.LockedError
+	if errors.As(err, &locked) {
+		return &pb.DisableTOTPResp{Success: false}, accountLockedError(locked.RetryAfter)
+	}
+	if errors.Is(err, errcode.UserNotFound) {
+		return &pb.DisableTOTPResp{Success: false}, ErrUserNotFound
+	}
+	if errors.Is(err, errcode.PasswordIncorrect) {
+		return &pb.DisableTOTPResp{Success: false}, ErrPasswordIncorrect
+	}
+	if errors.Is(err, errcode.TwoFactorNotEnrolled) {
+		return &pb.DisableTOTPResp{Success: false}, ErrTwoFactorNotEnabled
+	}
+	if errors.Is(err, errcode.TwoFactorCodeInvalid) {
+		return &pb.DisableTOTPResp{Success: false}, ErrTwoFactorCode
+	}
+	if err != nil {
+		return &pb.DisableTOTPResp{Success: false}, ErrTwoFactor
+	}
+	return &pb.DisableTOTPResp{Success: true}, nil
+}
+func (s *UserServiceService) VerifySecondFactor(ctx context.Context, req *pb.VerifySecondFactorReq) (*pb.VerifySecondFactorResp, error) {
+	user, client, err := s.twoFactorHandler.CompleteLogin(ctx, req.GetChallengeId(), req.GetCode())
+	var locked *errcode.LockedError
+	if errors.As(err, &locked) {
+		return &pb.VerifySecondFactorResp{}, accountLockedError(locked.RetryAfter)
+	}
+	if errors.Is(err, errcode.ChallengeInvalid) {
+		return &pb.VerifySecondFactorResp{}, ErrLoginChallenge
+	}
+	if errors.Is(err, errcode.TwoFactorCodeInvalid) {
+		return &pb.VerifySecondFactorResp{}, ErrTwoFactorCode
+	}
+	if err != nil {
+		return &pb.VerifySecondFactorResp{}, ErrLogin
+	}
+	pair, err := s.authHandler.IssueTokens(ctx, user, client)
+	if err != nil {
+		return &pb.VerifySecondFactorResp{}, ErrIssueToken
+	}
+	return &pb.VerifySecondFactorResp{
+		UserId:           s.publicID(user.ID),
+		SessionId:        pair.SessionID,
+		AccessToken:      pair.AccessToken,
+		RefreshToken:     pair.RefreshToken,
+		AccessExpiresAt:  pair.AccessExpiresAt.Unix(),
+		RefreshExpiresAt: pair.RefreshExpiresAt.Unix(),
+	}, nil
+}
+
+func (s *UserServiceService) SendPhoneCode(ctx context.Context, req *pb.SendPhoneCodeReq) (*pb.SendPhoneCodeResp, error) {
+	userID, err := s.userID(req.GetUserId())
+	if err != nil {
+		return &pb.SendPhoneCodeResp{}, err
+	}
+	_, cooldown, err := s.userHandler.SendPhoneCode(ctx, userID, req.GetPhone())
+	if errors.Is(err, errcode.PhoneInvalid) {
+		return &pb.SendPhoneCodeResp{}, ErrPhoneInvalid
+	}
+	if errors.Is(err, errcode.PhoneAlreadyUsed) {
+		return &pb.SendPhoneCodeResp{}, ErrPhoneAlreadyUsed
+	}
+	if errors.Is(err, errcode.UserNotFound) {
+		return &pb.SendPhoneCodeResp{}, ErrUserNotFound
+	}
+	if err != nil {
+		return &pb.SendPhoneCodeResp{}, verifyCodeError(err, ErrSendVerifyCode)
+	}
+	return &pb.SendPhoneCodeResp{
+		Sent:            true,
+		CooldownSeconds: ceilSeconds(cooldown),
+	}, nil
+}
+func (s *UserServiceService) VerifyPhone(ctx context.Context, req *pb.VerifyPhoneReq) (*pb.VerifyPhoneResp, error) {
+	userID, err := s.userID(req.GetUserId())
+	if err != nil {
+		return &pb.VerifyPhoneResp{}, err
+	}
+	phone, err := s.userHandler.VerifyPhone(ctx, userID, req.GetPhone(), req.GetVerifyCode())
+	if errors.Is(err, errcode.PhoneInvalid) {
+		return &pb.VerifyPhoneResp{}, ErrPhoneInvalid
+	}
+	if errors.Is(err, errcode.PhoneAlreadyUsed) {
+		return &pb.VerifyPhoneResp{}, ErrPhoneAlreadyUsed
+	}
+	if err != nil {
+		return &pb.VerifyPhoneResp{}, phoneCodeError(err, ErrVerifyPhone)
+	}
+	return &pb.VerifyPhoneResp{Phone: phone}, nil
+}
+func (s *UserServiceService) RequestEmailChange(ctx context.Context, req *pb.RequestEmailChangeReq) (*pb.RequestEmailChangeResp, error) {
+	userID, err := s.userID(req.GetUserId())
+	if err != nil {
+		return &pb.RequestEmailChangeResp{}, err
+	}
+	newEmail, err := s.userHandler.CanonicalEmail(req.GetNewEmail())
+	if err != nil {
+		return &pb.RequestEmailChangeResp{}, ErrEmailInvalid
+	}
+	cooldown, err := s.userHandler.RequestEmailChange(ctx, userID, newEmail, req.GetPassword(), s.clientInfo(ctx, "").IP)
+	var locked *errcode.LockedError
+	if errors.As(err, &locked) {
+		return &pb.RequestEmailChangeResp{}, accountLockedError(locked.RetryAfter)
+	}
+	if errors.Is(err, errcode.UserNotFound) {
+		return &pb.RequestEmailChangeResp{}, ErrUserNotFound
+	}
+	if errors.Is(err, errcode.PasswordIncorrect) {
+		return &pb.RequestEmailChangeResp{}, ErrPasswordIncorrect
+	}
+	if errors.Is(err, errcode.UserAlreadyExists) {
+		return &pb.RequestEmailChangeResp{}, ErrEmailExist
+	}
+	if err != nil {
+		return &pb.RequestEmailChangeResp{}, verifyCodeError(err, ErrSendVerifyCode)
+	}
+	return &pb.RequestEmailChangeResp{
+		Sent:            true,
+		CooldownSeconds: ceilSeconds(cooldown),
+	}, nil
+}
+func (s *UserServiceService) ConfirmEmailChange(ctx context.Context, req *pb.ConfirmEmailChangeReq) (*pb.ConfirmEmailChangeResp, error) {
+	userID, err := s.userID(req.GetUserId())
+	if err != nil {
+		return &pb.ConfirmEmailChangeResp{}, err
+	}
+	newEmail, err := s.userHandler.CanonicalEmail(req.GetNewEmail())
+	if err != nil {
+		return &pb.ConfirmEmailChangeResp{}, ErrEmailInvalid
+	}
+	err = s.userHandler.ConfirmEmailChange(ctx, userID, newEmail, req.GetVerifyCode(), s.clientInfo(ctx, ""))
+	if errors.Is(err, errcode.EmailChangeNotRequested) {
+		return &pb.ConfirmEmailChangeResp{}, ErrEmailChangeNotFound
+	}
+	if errors.Is(err, errcode.UserAlreadyExists) {
+		return &pb.ConfirmEmailChangeResp{}, ErrEmailExist
+	}
+	if errors.Is(err, errcode.UserNotFound) {
+		return &pb.ConfirmEmailChangeResp{}, ErrUserNotFound
+	}
+	if err != nil {
+		return &pb.ConfirmEmailChangeResp{}, verifyCodeError(err, ErrChangeEmail)
+	}
+	return &pb.ConfirmEmailChangeResp{Email: newEmail}, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/TiktokCommence/userService/internal/errcode"
+	"github.com/TiktokCommence/userService/internal/foundation/publicid"
+	"github.com/TiktokCommence/userService/internal/model"
+	"strconv"
+	"testing"
+)
+
+// fakeAuth 只实现访问令牌校验，令牌为 "token-" 加数字 ID
+type fakeAuth struct {
+	AuthHandler
+}
+
+func (fakeAuth) VerifyAccessToken(ctx context.Context, accessToken string) (model.TokenClaims, error) {
+	switch accessToken {
+	case "expired":
+		return model.TokenClaims{}, errcode.TokenExpired
+	case "broken":
+		return model.TokenClaims{}, errors.New("redis is down")
+	}
+	if len(accessToken) > len("token-") {
+		if id, err := strconv.ParseUint(accessToken[len("token-"):], 10, 64); err == nil {
+			return model.TokenClaims{UserID: id, SessionID: "session"}, nil
+		}
+	}
+	return model.TokenClaims{}, errcode.TokenInvalid
+}
+
+func newTestService(t *testing.T) *UserServiceService {
+	ids, err := publicid.New([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewUserServiceService(nil, fakeAuth{}, nil, ids, nil)
+}
+
+func TestUserServiceService_UserID(t *testing.T) {
+	s := newTestService(t)
+	public := s.publicID(42)
+	if public == "" || public == "42" {
+		t.Fatalf("expected an opaque public id, got %q", public)
+	}
+	cases := []struct {
+		name   string
+		input  string
+		expect uint64
+		err    error
+	}{
+		{"public", public, 42, nil},
+		// 数据库中的数字 ID 不能直接使用
+		{"numeric", "42", 0, ErrUserIDInvalid},
+		{"empty", "", 0, ErrUserIDInvalid},
+		{"invalid char", public[:len(public)-1] + "-", 0, ErrUserIDInvalid},
+		{"too long", public + "0", 0, ErrUserIDInvalid},
+	}
+	for _, c := range cases {
+		id, err := s.userID(c.input)
+		if !errors.Is(err, c.err) || id != c.expect {
+			t.Errorf("%s: userID(%q) = %d, %v, want %d, %v", c.name, c.input, id, err, c.expect, c.err)
+		}
+	}
+	if s.publicID(0) != "" {
+		t.Error("expected empty public id for id 0")
+	}
+}
+
+func TestUserServiceService_TokenUserID(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	fallback := errors.New("fallback")
+	cases := []struct {
+		name     string
+		token    string
+		publicID string
+		expect   uint64
+		err      error
+	}{
+		{"token only", "token-7", "", 7, nil},
+		{"same user", "token-7", s.publicID(7), 7, nil},
+		// 请求中的用户 ID 与令牌不一致时以令牌为准拒绝请求
+		{"other user", "token-7", s.publicID(8), 0, ErrAccessToken},
+		{"invalid user id", "token-7", "7", 0, ErrAccessToken},
+		{"invalid token", "forged", s.publicID(7), 0, ErrAccessToken},
+		{"expired token", "expired", "", 0, ErrAccessToken},
+		{"other error", "broken", "", 0, fallback},
+	}
+	for _, c := range cases {
+		id, err := s.tokenUserID(ctx, c.token, c.publicID, fallback)
+		if !errors.Is(err, c.err) || id != c.expect {
+			t.Errorf("%s: got %d, %v, want %d, %v", c.name, id, err, c.expect, c.err)
+		}
+	}
+}